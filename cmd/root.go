@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -37,6 +38,7 @@ var (
 	IterationTimeout int
 	Class_string     string
 	NanoSeconds      bool
+	Modules_string   string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -49,9 +51,28 @@ and parsing raw DNS packets.
 
 ZDNS also includes its own recursive resolution and a cache to further optimize performance.`,
 	ValidArgs: zdns.Validlookups(),
-	Args:      cobra.ExactValidArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if Modules_string != "" {
+			GC.Modules = nil
+			for _, module := range strings.Split(Modules_string, ",") {
+				GC.Modules = append(GC.Modules, strings.ToUpper(strings.TrimSpace(module)))
+			}
+		}
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		// the module can instead be given by --modules
+		if Modules_string != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("a module can't be given together with --modules")
+			}
+			return nil
+		}
+		return cobra.ExactValidArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		GC.Module = strings.ToUpper(args[0])
+		if len(args) > 0 {
+			GC.Module = strings.ToUpper(args[0])
+		}
 		zdns.Run(GC, cmd.Flags(),
 			&Timeout, &IterationTimeout,
 			&Class_string, &Servers_string,
@@ -88,9 +109,23 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&GC.MetadataFormat, "metadata-passthrough", false, "if input records have the form 'name,METADATA', METADATA will be propagated to the output")
 	rootCmd.PersistentFlags().BoolVar(&GC.IterativeResolution, "iterative", false, "Perform own iteration instead of relying on recursive resolver")
 	rootCmd.PersistentFlags().StringVar(&GC.InputFilePath, "input-file", "-", "names to read")
+	rootCmd.PersistentFlags().StringVar(&GC.InputFormat, "input-format", "text", "format of input lines. Options: text, jsonl (one JSON object per line, see README)")
+	rootCmd.PersistentFlags().BoolVar(&GC.ExpandRanges, "expand-ranges", false, "expand input lines that start with a CIDR block (e.g., 192.0.2.0/24) or address range (e.g., 192.0.2.1-192.0.2.9) into one line per address, skipping addresses in --blacklist-file")
+	rootCmd.PersistentFlags().BoolVar(&GC.PermuteRanges, "permute-ranges", false, "visit the addresses of each expanded range in a random order")
+	rootCmd.PersistentFlags().Int64Var(&GC.PermutationSeed, "permutation-seed", 0, "seed for --permute-ranges, to make the order repeatable. Random if 0")
 	rootCmd.PersistentFlags().StringVar(&GC.OutputFilePath, "output-file", "-", "where should JSON output be saved")
+	rootCmd.PersistentFlags().StringVar(&GC.OutputFormat, "output-format", "json", "format of the output. Options: json, csv, tsv (one row per record), text (like dig), parquet, protobuf (length-delimited), zone (RFC 1035 master file), see README")
+	rootCmd.PersistentFlags().StringVar(&GC.InputCompression, "input-compression", "auto", "compression of the input file. Options: auto (detect gzip and zstd from the file contents), none, gzip, zstd")
+	rootCmd.PersistentFlags().StringVar(&GC.OutputCompression, "output-compression", "auto", "compression of the output and metadata files. Options: auto (.gz and .zst file extensions), none, gzip, zstd")
+	rootCmd.PersistentFlags().IntVar(&GC.OutputRotateRecords, "output-rotate-records", 0, "start a new output file after this many records. --output-file is then a template, see README")
+	rootCmd.PersistentFlags().Int64Var(&GC.OutputRotateBytes, "output-rotate-bytes", 0, "start a new output file after this many bytes (before compression)")
+	rootCmd.PersistentFlags().DurationVar(&GC.OutputRotateInterval, "output-rotate-interval", 0, "start a new output file at this interval (e.g., 15m)")
+	rootCmd.PersistentFlags().IntVar(&GC.OutputShards, "output-shards", 1, "split output across this many files by a hash of the name. --output-file must contain {shard}")
 	rootCmd.PersistentFlags().StringVar(&GC.MetadataFilePath, "metadata-file", "", "where should JSON metadata be saved")
 	rootCmd.PersistentFlags().StringVar(&GC.LogFilePath, "log-file", "", "where should JSON logs be saved")
+	rootCmd.PersistentFlags().StringVar(&GC.StatusUpdatesFilePath, "status-updates-file", "", "where should periodic status updates be written (- for stderr). Disabled if empty")
+	rootCmd.PersistentFlags().StringVar(&GC.MetricsAddr, "metrics-addr", "", "address (e.g., localhost:9153) on which to serve Prometheus metrics at /metrics. Disabled if empty")
+	rootCmd.PersistentFlags().DurationVar(&GC.StatusUpdatesInterval, "status-updates-interval", time.Second, "how often status updates should be written")
 
 	rootCmd.PersistentFlags().StringVar(&GC.ResultVerbosity, "result-verbosity", "normal", "Sets verbosity of each output record. Options: short, normal, long, trace")
 	rootCmd.PersistentFlags().StringVar(&GC.IncludeInOutput, "include-fields", "", "Comma separated list of fields to additionally output beyond result verbosity. Options: class, protocol, ttl, resolver, flags")
@@ -101,6 +136,7 @@ func init() {
 	rootCmd.PersistentFlags().IntVar(&GC.CacheSize, "cache-size", 10000, "how many items can be stored in internal recursive cache")
 	rootCmd.PersistentFlags().BoolVar(&GC.TCPOnly, "tcp-only", false, "Only perform lookups over TCP")
 	rootCmd.PersistentFlags().BoolVar(&GC.UDPOnly, "udp-only", false, "Only perform lookups over UDP")
+	rootCmd.PersistentFlags().BoolVar(&GC.ModulePerLine, "module-per-line", false, "if input records have the form 'name,MODULE[,nameServer]', MODULE (a module or query type, e.g., MX) is used for that name instead of the module given on the command line")
 	rootCmd.PersistentFlags().BoolVar(&GC.NameServerMode, "name-server-mode", false, "Treats input as nameservers to query with a static query rather than queries to send to a static name server")
 
 	rootCmd.PersistentFlags().StringVar(&Servers_string, "name-servers", "", "List of DNS servers to use. Can be passed as comma-delimited string or via @/path/to/file. If no port is specified, defaults to 53.")
@@ -109,6 +145,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&Config_file, "conf-file", "/etc/resolv.conf", "config file for DNS servers")
 	rootCmd.PersistentFlags().IntVar(&Timeout, "timeout", 15, "timeout for resolving an individual name")
 	rootCmd.PersistentFlags().IntVar(&IterationTimeout, "iteration-timeout", 4, "timeout for resolving a single iteration in an iterative query")
+	rootCmd.PersistentFlags().StringVar(&Modules_string, "modules", "", "comma-delimited list of modules (e.g., A,AAAA,MXLOOKUP) to run over each input name, producing one combined record per name")
 	rootCmd.PersistentFlags().StringVar(&Class_string, "class", "INET", "DNS class to query. Options: INET, CSNET, CHAOS, HESIOD, NONE, ANY. Default: INET.")
 	rootCmd.PersistentFlags().BoolVar(&NanoSeconds, "nanoseconds", false, "Use nanosecond resolution timestamps")
 
@@ -116,6 +153,14 @@ func init() {
 	rootCmd.PersistentFlags().Bool("ipv6-lookup", false, "Perform an IPv6 Lookup in modules")
 	rootCmd.PersistentFlags().String("blacklist-file", "", "blacklist file for servers to exclude from lookups")
 	rootCmd.PersistentFlags().Int("mx-cache-size", 1000, "number of records to store in MX -> A/AAAA cache")
+	rootCmd.PersistentFlags().Bool("spf-evaluate", false, "SPF: parse the record and expand its includes, counting DNS and void lookups against the limits of RFC 7208")
+	rootCmd.PersistentFlags().String("spf-ip", "", "SPF: evaluate the policy for mail from this IP, giving pass, fail, softfail, neutral, none, permerror or temperror. Implies --spf-evaluate")
+	rootCmd.PersistentFlags().String("spf-sender", "", "SPF: sender address (or domain) for macros. Default: postmaster@ the looked up name")
+	rootCmd.PersistentFlags().String("dkim-selectors", "", "DKIM: comma-delimited list of selectors to look up for each domain, instead of a list of common selectors. Input lines of the form 'domain,selector' look up only that selector")
+	rootCmd.PersistentFlags().Bool("mta-sts-fetch-policy", false, "MTASTS, EMAILSEC: fetch the MTA-STS policy from https://mta-sts.<domain>/.well-known/mta-sts.txt and check the MX records of the domain against it")
+	rootCmd.PersistentFlags().String("bimi-selector", "default", "BIMI: selector of the records looked up for names given without one, i.e., <selector>._bimi.<domain>")
+	rootCmd.PersistentFlags().String("ca-domain", "", "CAALOOKUP: issuer domain of a CA (e.g., letsencrypt.org) to evaluate whether it may issue certificates and wildcard certificates for each name")
+	rootCmd.PersistentFlags().String("axfr-zone-dir", "", "directory to write each successful AXFR to as a zone file (ZONE_SERVER.zone). Disabled if empty")
 }
 
 // initConfig reads in config file and ENV variables if set.
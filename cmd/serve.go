@@ -0,0 +1,57 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zmap/zdns/internal/util"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve lookups over HTTP and gRPC",
+	Long: `serve runs zdns as a long-lived service that answers lookups over HTTP and gRPC,
+keeping module state such as sockets and the iterative cache warm between requests.
+
+Lookups are made at /lookup, either with a GET request:
+
+  /lookup?module=A&name=example.com&nameserver=8.8.8.8
+
+or by POSTing a JSON object or an array of JSON objects with the module, name
+and (optional) nameserver fields. Each lookup is answered with the same JSON
+result that the CLI would print.
+
+If --grpc-addr is set, the bidirectional streaming Lookup RPC of the Zdns
+service defined in pkg/zdns/zdnspb/zdns.proto is served there as well.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		zdns.Serve(GC, cmd.Flags(),
+			&Timeout, &IterationTimeout,
+			&Class_string, &Servers_string,
+			&Config_file, &Localaddr_string,
+			&Localif_string, &NanoSeconds)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.PersistentFlags().StringVar(&GC.HTTPAddr, "http-addr", "localhost:8080", "address on which to serve lookups over HTTP. Disabled if empty")
+	serveCmd.PersistentFlags().StringVar(&GC.GRPCAddr, "grpc-addr", "", "address on which to serve lookups over gRPC. Disabled if empty")
+
+	util.BindFlags(serveCmd, viper.GetViper(), util.EnvPrefix)
+}
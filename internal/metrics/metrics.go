@@ -0,0 +1,102 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package metrics holds the Prometheus metrics that ZDNS exports while it
+// runs. The metrics are always collected; they're only served when Serve is
+// called (i.e., when --metrics-addr is set).
+package metrics
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+const namespace = "zdns"
+
+var (
+	Lookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lookups_total",
+		Help:      "Completed lookups (one per input name) by module and result status.",
+	}, []string{"module", "status"})
+
+	LookupsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lookups_in_flight",
+		Help:      "Lookups that have started but not yet completed.",
+	})
+
+	QueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "query_duration_seconds",
+		Help:      "Time taken by a single DNS query on the wire, including queries that timed out.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"protocol"})
+
+	Retries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "query_retries_total",
+		Help:      "DNS queries that were retried after a timeout or temporary failure.",
+	})
+
+	TCPFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "truncated_tcp_fallbacks_total",
+		Help:      "Truncated UDP responses that were retried over TCP.",
+	})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache requests by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	BlacklistHits = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blacklist_hits_total",
+		Help:      "Queries that were not sent because the server is in the blacklist.",
+	})
+)
+
+// CacheHit records a cache request against the named cache
+func CacheHit(cache string, hit bool) {
+	if hit {
+		CacheRequests.WithLabelValues(cache, "hit").Inc()
+	} else {
+		CacheRequests.WithLabelValues(cache, "miss").Inc()
+	}
+}
+
+// Serve starts serving the metrics at /metrics on addr in the background.
+// An error is returned if addr can't be bound.
+func Serve(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.Serve(l, mux); err != nil {
+			log.Error("metrics server stopped: ", err)
+		}
+	}()
+	log.Info("serving metrics on ", l.Addr().String())
+	return nil
+}
@@ -0,0 +1,195 @@
+package iohandlers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression formats for input and output files. CompressionAuto detects
+// the format of input from its first bytes, and of output from the file
+// extension (.gz, .zst).
+const (
+	CompressionAuto = "auto"
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ValidCompression reports whether c is one of the Compression constants
+func ValidCompression(c string) bool {
+	switch c {
+	case "", CompressionAuto, CompressionNone, CompressionGzip, CompressionZstd:
+		return true
+	}
+	return false
+}
+
+// countingReader counts the bytes read through it, so that progress can be
+// reported in terms of the (possibly compressed) input file
+type countingReader struct {
+	r     io.Reader
+	count *int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddInt64(c.count, int64(n))
+	return n, err
+}
+
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m multiCloser) Close() error {
+	return closeAll(m.closers)
+}
+
+// closeAll closes every closer in order, returning the first error
+func closeAll(closers []io.Closer) error {
+	var err error
+	for _, c := range closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// openInput opens path ("-" or "" for stdin) for reading, decompressing it
+// according to compression. It returns the size of the file on disk (zero if
+// unknown), and counts the bytes read from disk in read.
+func openInput(path, compression string, read *int64) (io.ReadCloser, int64, error) {
+	var f *os.File
+	var fileCloser io.Closer = nopCloser{}
+	if path == "" || path == "-" {
+		f = os.Stdin
+	} else {
+		var err error
+		f, err = os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		fileCloser = f
+	}
+	var size int64
+	if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	br := bufio.NewReader(countingReader{f, read})
+	if compression == "" || compression == CompressionAuto {
+		compression = CompressionNone
+		// a short or empty input can't be compressed, so errors can be ignored here
+		magic, _ := br.Peek(len(zstdMagic))
+		if bytes.HasPrefix(magic, gzipMagic) {
+			compression = CompressionGzip
+		} else if bytes.HasPrefix(magic, zstdMagic) {
+			compression = CompressionZstd
+		}
+	}
+	switch compression {
+	case CompressionNone:
+		return multiCloser{br, []io.Closer{fileCloser}}, size, nil
+	case CompressionGzip:
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			fileCloser.Close()
+			return nil, 0, err
+		}
+		return multiCloser{gz, []io.Closer{gz, fileCloser}}, size, nil
+	case CompressionZstd:
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			fileCloser.Close()
+			return nil, 0, err
+		}
+		return multiCloser{zr, []io.Closer{zr.IOReadCloser(), fileCloser}}, size, nil
+	}
+	fileCloser.Close()
+	return nil, 0, fmt.Errorf("unknown compression: %s", compression)
+}
+
+type compressedWriter struct {
+	io.Writer
+	closers []io.Closer
+}
+
+// Close flushes the compressor before closing the file
+func (w compressedWriter) Close() error {
+	return closeAll(w.closers)
+}
+
+// nopCloser keeps stdin, stdout and stderr open when a file is closed
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// CreateOutput opens path for writing, truncating it, and compresses what
+// is written to it according to compression. "-" and "" mean stdout. The
+// returned writer must be closed to flush the compressor.
+func CreateOutput(path, compression string) (io.WriteCloser, error) {
+	return createOutput(path, compression, os.Stdout)
+}
+
+func createOutput(path, compression string, std *os.File) (io.WriteCloser, error) {
+	var f *os.File
+	var fileCloser io.Closer = nopCloser{}
+	if path == "" || path == "-" {
+		f = std
+	} else {
+		var err error
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return nil, err
+		}
+		fileCloser = f
+	}
+	switch outputCompression(path, compression) {
+	case CompressionNone:
+		return compressedWriter{f, []io.Closer{fileCloser}}, nil
+	case CompressionGzip:
+		gz := gzip.NewWriter(f)
+		return compressedWriter{gz, []io.Closer{gz, fileCloser}}, nil
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(f)
+		if err != nil {
+			fileCloser.Close()
+			return nil, err
+		}
+		return compressedWriter{zw, []io.Closer{zw, fileCloser}}, nil
+	}
+	fileCloser.Close()
+	return nil, fmt.Errorf("unknown compression: %s", compression)
+}
+
+// outputCompression resolves CompressionAuto using the extension of path
+func outputCompression(path, compression string) string {
+	if compression != "" && compression != CompressionAuto {
+		return compression
+	}
+	if strings.HasSuffix(path, ".gz") {
+		return CompressionGzip
+	} else if strings.HasSuffix(path, ".zst") || strings.HasSuffix(path, ".zstd") {
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// CreateMetadataOutput is like CreateOutput, except that "-" means stderr
+func CreateMetadataOutput(path, compression string) (io.WriteCloser, error) {
+	return createOutput(path, compression, os.Stderr)
+}
@@ -0,0 +1,59 @@
+package iohandlers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		file, compression string
+	}{
+		{"out.txt", CompressionAuto},
+		{"out.gz", CompressionAuto},
+		{"out.zst", CompressionAuto},
+		{"out.txt", CompressionGzip},
+		{"out.txt", CompressionZstd},
+	} {
+		path := filepath.Join(dir, tc.file)
+		w, err := CreateOutput(path, tc.compression)
+		assert.NilError(t, err)
+		_, err = w.Write([]byte("example.com\nexample.net\n"))
+		assert.NilError(t, err)
+		assert.NilError(t, w.Close())
+
+		// auto detection on input doesn't depend on the file name
+		var read int64
+		r, size, err := openInput(path, CompressionAuto, &read)
+		assert.NilError(t, err)
+		data, err := ioutil.ReadAll(r)
+		assert.NilError(t, err)
+		assert.NilError(t, r.Close())
+		assert.Equal(t, string(data), "example.com\nexample.net\n", tc)
+		assert.Equal(t, read, size, tc)
+	}
+}
+
+func TestCompressionDetectedFromExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.gz")
+	w, err := CreateOutput(path, CompressionAuto)
+	assert.NilError(t, err)
+	assert.NilError(t, w.Close())
+	var read int64
+	r, _, err := openInput(path, CompressionNone, &read)
+	assert.NilError(t, err)
+	data, err := ioutil.ReadAll(r)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data[:2]), string(gzipMagic))
+}
+
+func TestValidCompression(t *testing.T) {
+	assert.Assert(t, ValidCompression("zstd"))
+	assert.Assert(t, ValidCompression(""))
+	assert.Assert(t, !ValidCompression("bzip2"))
+}
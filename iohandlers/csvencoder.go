@@ -0,0 +1,322 @@
+package iohandlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CSVOptions controls the columns written by a CSV encoder
+type CSVOptions struct {
+	// separate fields with tabs instead of commas
+	TSV bool
+	// output groups (e.g., short, ttl) selected with --result-verbosity and
+	// --include-fields. Columns outside of them are left out, like the
+	// corresponding JSON fields.
+	Groups []string
+	// modules whose specific columns (e.g., MX preference) should be added.
+	// Nil adds the columns of every module.
+	Modules []string
+	// add a column with the module of each row, for --modules
+	ModuleColumn bool
+	// add columns for the Alexa rank and metadata of the input
+	AlexaRank bool
+	Metadata  bool
+}
+
+// csvRow is a single row of flattened output: a result together with one of
+// its records, if any
+type csvRow struct {
+	res    *flatResult
+	module string
+	// the record
+	section, rtype, ttl, answer, preference string
+	ipv4, ipv6                              []string
+}
+
+type csvColumn struct {
+	name string
+	// the column is written if any of the groups is selected. Nil means
+	// always.
+	groups []string
+	// the column is written if any of these modules is used. Nil means
+	// always.
+	modules []string
+	value   func(r *csvRow) string
+}
+
+var csvColumns = []csvColumn{
+	{name: "name", value: func(r *csvRow) string { return r.res.Name }},
+	{name: "status", value: func(r *csvRow) string { return r.res.Status }},
+	{name: "type", value: func(r *csvRow) string { return r.rtype }},
+	{name: "ttl", groups: []string{"ttl", "normal", "long", "trace"}, value: func(r *csvRow) string { return r.ttl }},
+	{name: "answer", value: func(r *csvRow) string { return r.answer }},
+	{name: "preference", modules: []string{"MXLOOKUP", "MX"}, value: func(r *csvRow) string { return r.preference }},
+	{name: "ipv4_addresses", modules: []string{"MXLOOKUP", "NSLOOKUP"}, value: func(r *csvRow) string { return strings.Join(r.ipv4, ";") }},
+	{name: "ipv6_addresses", modules: []string{"MXLOOKUP", "NSLOOKUP"}, value: func(r *csvRow) string { return strings.Join(r.ipv6, ";") }},
+	{name: "section", groups: []string{"long", "trace"}, value: func(r *csvRow) string { return r.section }},
+	{name: "nameserver", groups: []string{"resolver", "normal", "long", "trace"}, value: func(r *csvRow) string { return r.res.resolver() }},
+	{name: "class", groups: []string{"long", "trace"}, value: func(r *csvRow) string { return r.res.Class }},
+	{name: "error", value: func(r *csvRow) string { return r.res.Error }},
+	{name: "timestamp", value: func(r *csvRow) string { return r.res.Timestamp }},
+}
+
+// flatResult is the part of a JSON result that is flattened into rows
+type flatResult struct {
+	Name      string                 `json:"name"`
+	Class     string                 `json:"class"`
+	AlexaRank json.Number            `json:"alexa_rank"`
+	Metadata  json.RawMessage        `json:"metadata"`
+	Status    string                 `json:"status"`
+	Error     string                 `json:"error"`
+	Timestamp string                 `json:"timestamp"`
+	Data      json.RawMessage        `json:"data"`
+	Results   map[string]*flatResult `json:"results"`
+	data      map[string]interface{}
+}
+
+func (r *flatResult) resolver() string {
+	s, _ := r.data["resolver"].(string)
+	return s
+}
+
+type csvEncoder struct {
+	opts    CSVOptions
+	columns []csvColumn
+	w       io.Writer
+	csv     *csv.Writer
+}
+
+// NewCSVEncoder returns an EncoderFactory that flattens results into one row
+// per record (e.g., answer, MX exchange or address), with a header row at the
+// start of every file. Results without records get a single row.
+func NewCSVEncoder(opts CSVOptions) EncoderFactory {
+	var columns []csvColumn
+	if opts.ModuleColumn {
+		columns = append(columns, csvColumn{name: "module", value: func(r *csvRow) string { return r.module }})
+	}
+	for _, c := range csvColumns {
+		if c.name == "status" {
+			if opts.AlexaRank {
+				columns = append(columns, csvColumn{name: "alexa_rank", value: func(r *csvRow) string { return r.res.AlexaRank.String() }})
+			}
+			if opts.Metadata {
+				columns = append(columns, csvColumn{name: "metadata", value: func(r *csvRow) string { return jsonString(r.res.Metadata) }})
+			}
+		}
+		if (c.groups == nil || intersects(c.groups, opts.Groups)) &&
+			(c.modules == nil || opts.Modules == nil || intersects(c.modules, opts.Modules)) {
+			columns = append(columns, c)
+		}
+	}
+	return func(w io.Writer) (Encoder, error) {
+		e := &csvEncoder{opts: opts, columns: columns, w: w}
+		if !opts.TSV {
+			e.csv = csv.NewWriter(w)
+		}
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.name
+		}
+		return e, e.write(header)
+	}
+}
+
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tsvEscaper escapes the characters that would break up a TSV field, the way
+// e.g. PostgreSQL's text format does
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+func (e *csvEncoder) write(fields []string) error {
+	if e.csv != nil {
+		e.csv.Write(fields)
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	for i, f := range fields {
+		fields[i] = tsvEscaper.Replace(f)
+	}
+	_, err := io.WriteString(e.w, strings.Join(fields, "\t")+"\n")
+	return err
+}
+
+func (e *csvEncoder) Encode(result string) error {
+	var res flatResult
+	if err := json.Unmarshal([]byte(result), &res); err != nil {
+		return err
+	}
+	var rows []csvRow
+	if len(res.Results) > 0 {
+		modules := make([]string, 0, len(res.Results))
+		for m := range res.Results {
+			modules = append(modules, m)
+		}
+		sort.Strings(modules)
+		for _, m := range modules {
+			sub := res.Results[m]
+			// the name and input fields are only given once for all modules
+			sub.Name, sub.AlexaRank, sub.Metadata = res.Name, res.AlexaRank, res.Metadata
+			rows = append(rows, flatten(sub, m)...)
+		}
+	} else {
+		rows = flatten(&res, "")
+	}
+	fields := make([]string, len(e.columns))
+	for i := range rows {
+		for j, c := range e.columns {
+			fields[j] = c.value(&rows[i])
+		}
+		if err := e.write(fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvEncoder) Close() error {
+	return nil
+}
+
+// flatten turns a result into rows, based on the shape of its data
+func flatten(res *flatResult, module string) []csvRow {
+	if len(res.Data) > 0 {
+		d := json.NewDecoder(bytes.NewReader(res.Data))
+		d.UseNumber()
+		// data that isn't an object ends up in the answer column below
+		d.Decode(&res.data)
+	}
+	var rows []csvRow
+	add := func(r csvRow) {
+		r.res, r.module = res, module
+		rows = append(rows, r)
+	}
+	data := res.data
+	switch {
+	case data["exchanges"] != nil || data["servers"] != nil:
+		// MXLOOKUP and NSLOOKUP
+		for _, key := range []string{"exchanges", "servers"} {
+			for _, s := range objects(data[key]) {
+				add(csvRow{
+					section:    "answer",
+					rtype:      str(s["type"]),
+					ttl:        str(s["ttl"]),
+					answer:     str(s["name"]),
+					preference: str(s["preference"]),
+					ipv4:       strs(s["ipv4_addresses"]),
+					ipv6:       strs(s["ipv6_addresses"]),
+				})
+			}
+		}
+	case data["answers"] != nil || data["authorities"] != nil || data["additionals"] != nil || data["resolver"] != nil:
+		// raw lookups
+		for _, section := range [][2]string{{"answers", "answer"}, {"authorities", "authority"}, {"additionals", "additional"}} {
+			for _, a := range objects(data[section[0]]) {
+				add(answerRow(section[1], a))
+			}
+		}
+	case data["ipv4_addresses"] != nil || data["ipv6_addresses"] != nil:
+		// ALOOKUP
+		for _, ip := range strs(data["ipv4_addresses"]) {
+			add(csvRow{section: "answer", rtype: "A", answer: ip})
+		}
+		for _, ip := range strs(data["ipv6_addresses"]) {
+			add(csvRow{section: "answer", rtype: "AAAA", answer: ip})
+		}
+	case len(data) == 1:
+		// modules like SPF, DMARC and BINDVERSION that return a single value
+		for _, v := range data {
+			add(csvRow{answer: str(v)})
+		}
+	case len(res.Data) > 0 && string(res.Data) != "null":
+		add(csvRow{answer: jsonString(res.Data)})
+	}
+	if len(rows) == 0 {
+		add(csvRow{})
+	}
+	return rows
+}
+
+func answerRow(section string, a map[string]interface{}) csvRow {
+	r := csvRow{
+		section:    section,
+		rtype:      str(a["type"]),
+		ttl:        str(a["ttl"]),
+		answer:     str(a["answer"]),
+		preference: str(a["preference"]),
+	}
+	if _, ok := a["answer"]; !ok {
+		// records like CAA or DS that are made up of several fields
+		rest := make(map[string]interface{})
+		for k, v := range a {
+			switch k {
+			case "name", "type", "class", "ttl":
+			default:
+				rest[k] = v
+			}
+		}
+		b, _ := json.Marshal(rest)
+		r.answer = string(b)
+	}
+	return r
+}
+
+func objects(v interface{}) []map[string]interface{} {
+	var objs []map[string]interface{}
+	if l, ok := v.([]interface{}); ok {
+		for _, o := range l {
+			if m, ok := o.(map[string]interface{}); ok {
+				objs = append(objs, m)
+			}
+		}
+	}
+	return objs
+}
+
+func strs(v interface{}) []string {
+	var s []string
+	if l, ok := v.([]interface{}); ok {
+		for _, x := range l {
+			s = append(s, str(x))
+		}
+	}
+	return s
+}
+
+// str formats a JSON value for a single field
+func str(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jsonString returns a JSON string as is, and other JSON values encoded
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,52 @@
+package iohandlers
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func encodeCSV(t *testing.T, opts CSVOptions, results ...string) string {
+	var b bytes.Buffer
+	enc, err := NewCSVEncoder(opts)(&b)
+	assert.NilError(t, err)
+	for _, r := range results {
+		assert.NilError(t, enc.Encode(r))
+	}
+	assert.NilError(t, enc.Close())
+	return b.String()
+}
+
+func TestCSVAnswers(t *testing.T) {
+	out := encodeCSV(t, CSVOptions{Groups: []string{"short"}, Modules: []string{"A"}},
+		`{"name":"example.com","status":"NOERROR","timestamp":"t","data":{"answers":[{"type":"A","name":"example.com","answer":"192.0.2.1"},{"type":"A","name":"example.com","answer":"192.0.2.2"}]}}`,
+		`{"name":"example.net","status":"NXDOMAIN","timestamp":"t","data":{"resolver":"8.8.8.8:53"}}`,
+		`{"name":"a,b","status":"ILLEGAL_INPUT","error":"bad \"name\"","timestamp":"t"}`,
+	)
+	assert.Equal(t, out, `name,status,type,answer,error,timestamp
+example.com,NOERROR,A,192.0.2.1,,t
+example.com,NOERROR,A,192.0.2.2,,t
+example.net,NXDOMAIN,,,,t
+"a,b",ILLEGAL_INPUT,,,"bad ""name""",t
+`)
+}
+
+func TestCSVModuleColumns(t *testing.T) {
+	out := encodeCSV(t, CSVOptions{Groups: []string{"normal"}, Modules: []string{"MXLOOKUP"}},
+		`{"name":"example.com","status":"NOERROR","timestamp":"t","data":{"exchanges":[{"name":"mx.example.com","type":"MX","preference":10,"ipv4_addresses":["192.0.2.1","192.0.2.2"],"ttl":300}]}}`,
+	)
+	assert.Equal(t, out, `name,status,type,ttl,answer,preference,ipv4_addresses,ipv6_addresses,nameserver,error,timestamp
+example.com,NOERROR,MX,300,mx.example.com,10,192.0.2.1;192.0.2.2,,,,t
+`)
+}
+
+func TestTSVCombinedModules(t *testing.T) {
+	out := encodeCSV(t, CSVOptions{TSV: true, Groups: []string{"short"}, Modules: []string{"ALOOKUP", "SPF"}, ModuleColumn: true, Metadata: true},
+		`{"name":"example.com","metadata":{"id":1},"timestamp":"t","results":{"SPF":{"status":"NOERROR","data":{"spf":"v=spf1\t-all"}},"ALOOKUP":{"status":"NOERROR","data":{"ipv4_addresses":["192.0.2.1"],"ipv6_addresses":["2001:db8::1"]}}}}`,
+	)
+	assert.Equal(t, out, "module\tname\tmetadata\tstatus\ttype\tanswer\terror\ttimestamp\n"+
+		"ALOOKUP\texample.com\t{\"id\":1}\tNOERROR\tA\t192.0.2.1\t\t\n"+
+		"ALOOKUP\texample.com\t{\"id\":1}\tNOERROR\tAAAA\t2001:db8::1\t\t\n"+
+		"SPF\texample.com\t{\"id\":1}\tNOERROR\t\tv=spf1\\t-all\t\t\n")
+}
@@ -0,0 +1,57 @@
+package iohandlers
+
+import (
+	"io"
+)
+
+// Encoder writes results to an output file in some format. Results are passed
+// to it as JSON objects, the way ZDNS outputs them by default.
+type Encoder interface {
+	Encode(result string) error
+	// Close writes anything the encoder has buffered. It doesn't close the
+	// underlying writer.
+	Close() error
+}
+
+// EncoderFactory creates the Encoder for an output file. Output handlers call
+// it once for every file they write to.
+type EncoderFactory func(w io.Writer) (Encoder, error)
+
+type jsonEncoder struct {
+	w io.Writer
+}
+
+// NewJSONEncoder creates an Encoder that writes one JSON object per line. It
+// is the default encoder of the output handlers.
+func NewJSONEncoder(w io.Writer) (Encoder, error) {
+	return jsonEncoder{w}, nil
+}
+
+func (e jsonEncoder) Encode(result string) error {
+	_, err := io.WriteString(e.w, result+"\n")
+	return err
+}
+
+func (e jsonEncoder) Close() error {
+	return nil
+}
+
+// newEncoder creates an encoder for w using f, or NewJSONEncoder if f is nil
+func newEncoder(f EncoderFactory, w io.Writer) (Encoder, error) {
+	if f == nil {
+		f = NewJSONEncoder
+	}
+	return f(w)
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.count += int64(n)
+	return n, err
+}
@@ -2,14 +2,20 @@ package iohandlers
 
 import (
 	"bufio"
-	"os"
 	"sync"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type FileInputHandler struct {
 	filepath string
+	// one of the Compression constants; defaults to CompressionAuto
+	Compression string
+
+	// bytes read and total size of the input, for status updates
+	read  int64
+	total int64
 }
 
 func NewFileInputHandler(filepath string) *FileInputHandler {
@@ -22,16 +28,12 @@ func (h *FileInputHandler) FeedChannel(in chan<- interface{}, wg *sync.WaitGroup
 	defer close(in)
 	defer (*wg).Done()
 
-	var f *os.File
-	if h.filepath == "" || h.filepath == "-" {
-		f = os.Stdin
-	} else {
-		var err error
-		f, err = os.Open(h.filepath)
-		if err != nil {
-			log.Fatalf("unable to open input file: %v", err)
-		}
+	f, total, err := openInput(h.filepath, h.Compression, &h.read)
+	if err != nil {
+		log.Fatalf("unable to open input file: %v", err)
 	}
+	defer f.Close()
+	atomic.StoreInt64(&h.total, total)
 	s := bufio.NewScanner(f)
 	for s.Scan() {
 		in <- s.Text()
@@ -42,8 +44,19 @@ func (h *FileInputHandler) FeedChannel(in chan<- interface{}, wg *sync.WaitGroup
 	return nil
 }
 
+// Progress returns the number of bytes read so far and the size of the input
+// file, or zero if the input is not a regular file (e.g., a pipe). For
+// compressed input, both are in terms of the compressed file.
+func (h *FileInputHandler) Progress() (int64, int64) {
+	return atomic.LoadInt64(&h.read), atomic.LoadInt64(&h.total)
+}
+
 type FileOutputHandler struct {
 	filepath string
+	// one of the Compression constants; defaults to CompressionAuto
+	Compression string
+	// format of the output; defaults to NewJSONEncoder
+	Encoder EncoderFactory
 }
 
 func NewFileOutputHandler(filepath string) *FileOutputHandler {
@@ -55,19 +68,24 @@ func NewFileOutputHandler(filepath string) *FileOutputHandler {
 func (h *FileOutputHandler) WriteResults(results <-chan string, wg *sync.WaitGroup) error {
 	defer (*wg).Done()
 
-	var f *os.File
-	if h.filepath == "" || h.filepath == "-" {
-		f = os.Stdout
-	} else {
-		var err error
-		f, err = os.OpenFile(h.filepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-		if err != nil {
-			log.Fatalf("unable to open output file: %v", err)
-		}
-		defer f.Close()
+	f, err := CreateOutput(h.filepath, h.Compression)
+	if err != nil {
+		log.Fatalf("unable to open output file: %v", err)
+	}
+	enc, err := newEncoder(h.Encoder, f)
+	if err != nil {
+		log.Fatalf("unable to set up output format: %v", err)
 	}
 	for n := range results {
-		f.WriteString(n + "\n")
+		if err := enc.Encode(n); err != nil {
+			log.Fatalf("unable to write output: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		log.Fatalf("unable to write output: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("unable to write output file: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,252 @@
+package iohandlers
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/go-iptree/blacklist"
+)
+
+// RangeInputHandler reads lines like FileInputHandler, but expands lines whose
+// first field is a CIDR block (192.0.2.0/24, 2001:db8::/120) or an address
+// range (192.0.2.10-192.0.2.20) into one line per address. The rest of the
+// line (e.g., ",8.8.8.8") is kept on every expanded line. Addresses are
+// generated as they are needed, so even very large ranges don't use memory.
+// Other lines are passed through unchanged.
+type RangeInputHandler struct {
+	filepath  string
+	blacklist *blacklist.Blacklist
+	permute   bool
+	rng       *rand.Rand
+	// one of the Compression constants; defaults to CompressionAuto
+	Compression string
+
+	// bytes read and total size of the input, for status updates
+	read  int64
+	total int64
+}
+
+// NewRangeInputHandler creates a RangeInputHandler. Addresses covered by the
+// blacklist file (if any) are skipped. If permute is set, the addresses of
+// each range are visited in a random order derived from seed.
+func NewRangeInputHandler(filepath, blacklistPath string, permute bool, seed int64) (*RangeInputHandler, error) {
+	h := &RangeInputHandler{
+		filepath: filepath,
+		permute:  permute,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	if blacklistPath != "" {
+		h.blacklist = blacklist.New()
+		if err := h.blacklist.ParseFromFile(blacklistPath); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+func (h *RangeInputHandler) FeedChannel(in chan<- interface{}, wg *sync.WaitGroup) error {
+	defer close(in)
+	defer (*wg).Done()
+
+	f, total, err := openInput(h.filepath, h.Compression, &h.read)
+	if err != nil {
+		log.Fatalf("unable to open input file: %v", err)
+	}
+	defer f.Close()
+	atomic.StoreInt64(&h.total, total)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		field, rest := line, ""
+		if i := strings.IndexByte(line, ','); i >= 0 {
+			field, rest = line[:i], line[i:]
+		}
+		r, ok := parseIPRange(field)
+		if !ok {
+			in <- line
+		} else if err := h.expand(r, func(ip net.IP) {
+			if !h.isBlacklisted(ip) {
+				in <- ip.String() + rest
+			}
+		}); err != nil {
+			log.Fatalf("unable to expand %s: %v", field, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		log.Fatalf("input unable to read file: %v", err)
+	}
+	return nil
+}
+
+// Progress returns the number of bytes read so far and the size of the input
+// file, or zero if the input is not a regular file (e.g., a pipe)
+func (h *RangeInputHandler) Progress() (int64, int64) {
+	return atomic.LoadInt64(&h.read), atomic.LoadInt64(&h.total)
+}
+
+func (h *RangeInputHandler) isBlacklisted(ip net.IP) bool {
+	if h.blacklist == nil {
+		return false
+	}
+	blacklisted, err := h.blacklist.IsBlacklisted(ip.String())
+	return err == nil && blacklisted
+}
+
+func (h *RangeInputHandler) expand(r ipRange, fn func(net.IP)) error {
+	if !h.permute {
+		for a := r.start; ; a = a.add(1) {
+			fn(a.ip(r.v4))
+			if a == r.end {
+				return nil
+			}
+		}
+	}
+	size := r.end.sub(r.start)
+	if size.hi != 0 || size.lo >= 1<<63 {
+		return errors.New("range is too large to permute")
+	}
+	p := newPermutation(size.lo+1, h.rng)
+	for i := uint64(0); i <= size.lo; i++ {
+		fn(r.start.add(p.at(i)).ip(r.v4))
+	}
+	return nil
+}
+
+// addr128 is an IPv4 or IPv6 address as a 128-bit integer
+type addr128 struct {
+	hi, lo uint64
+}
+
+func addrFromIP(ip net.IP) addr128 {
+	ip = ip.To16()
+	return addr128{binary.BigEndian.Uint64(ip[:8]), binary.BigEndian.Uint64(ip[8:])}
+}
+
+func (a addr128) ip(v4 bool) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	binary.BigEndian.PutUint64(ip[:8], a.hi)
+	binary.BigEndian.PutUint64(ip[8:], a.lo)
+	if v4 {
+		return ip.To4()
+	}
+	return ip
+}
+
+func (a addr128) add(n uint64) addr128 {
+	lo, carry := bits.Add64(a.lo, n, 0)
+	return addr128{a.hi + carry, lo}
+}
+
+func (a addr128) sub(b addr128) addr128 {
+	lo, borrow := bits.Sub64(a.lo, b.lo, 0)
+	return addr128{a.hi - b.hi - borrow, lo}
+}
+
+func (a addr128) less(b addr128) bool {
+	return a.hi < b.hi || (a.hi == b.hi && a.lo < b.lo)
+}
+
+// ipRange is an inclusive range of addresses of a single family
+type ipRange struct {
+	start, end addr128
+	v4         bool
+}
+
+// parseIPRange parses a CIDR block or a start-end range. Anything else,
+// including malformed ranges, is reported as not being a range.
+func parseIPRange(s string) (ipRange, bool) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return ipRange{}, false
+		}
+		r := ipRange{start: addrFromIP(n.IP), v4: n.IP.To4() != nil}
+		mask := n.Mask
+		if r.v4 {
+			// line the mask up with the IPv4-mapped address
+			mask = append(net.CIDRMask(96, 128)[:12], mask...)
+		}
+		inv := make(net.IP, net.IPv6len)
+		for i := range inv {
+			inv[i] = ^mask[i]
+		}
+		host := addrFromIP(inv)
+		r.end = addr128{r.start.hi | host.hi, r.start.lo | host.lo}
+		return r, true
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return ipRange{}, false
+	}
+	start, end := net.ParseIP(strings.TrimSpace(parts[0])), net.ParseIP(strings.TrimSpace(parts[1]))
+	if start == nil || end == nil || (start.To4() != nil) != (end.To4() != nil) {
+		return ipRange{}, false
+	}
+	r := ipRange{start: addrFromIP(start), end: addrFromIP(end), v4: start.To4() != nil}
+	if r.end.less(r.start) {
+		return ipRange{}, false
+	}
+	return r, true
+}
+
+// permutation is a random bijection on [0, n). Like ZMap's cyclic groups, it
+// lets us visit every address of a range in a random order without keeping
+// track of which addresses have been visited. It is a small Feistel network on
+// the smallest even number of bits that can represent n, with cycle walking
+// to map it back into [0, n).
+type permutation struct {
+	n    uint64
+	half uint
+	mask uint64
+	keys [4]uint64
+}
+
+func newPermutation(n uint64, rng *rand.Rand) *permutation {
+	width := uint(bits.Len64(n - 1))
+	if width < 2 {
+		width = 2
+	}
+	width += width % 2
+	p := &permutation{n: n, half: width / 2}
+	p.mask = 1<<p.half - 1
+	for i := range p.keys {
+		p.keys[i] = rng.Uint64()
+	}
+	return p
+}
+
+// mix is the splitmix64 finalizer
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func (p *permutation) encrypt(x uint64) uint64 {
+	l, r := x>>p.half, x&p.mask
+	for _, k := range p.keys {
+		l, r = r, l^(mix(r^k)&p.mask)
+	}
+	return l<<p.half | r
+}
+
+// at returns the i'th element of the permutation
+func (p *permutation) at(i uint64) uint64 {
+	x := p.encrypt(i)
+	for x >= p.n {
+		x = p.encrypt(x)
+	}
+	return x
+}
@@ -0,0 +1,64 @@
+package iohandlers
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func expandAll(t *testing.T, h *RangeInputHandler, s string) []string {
+	r, ok := parseIPRange(s)
+	assert.Assert(t, ok, s)
+	var ips []string
+	assert.NilError(t, h.expand(r, func(ip net.IP) {
+		ips = append(ips, ip.String())
+	}))
+	return ips
+}
+
+func TestParseIPRange(t *testing.T) {
+	h := &RangeInputHandler{}
+	assert.DeepEqual(t, expandAll(t, h, "192.0.2.5/30"), []string{"192.0.2.4", "192.0.2.5", "192.0.2.6", "192.0.2.7"})
+	assert.DeepEqual(t, expandAll(t, h, "192.0.2.255-192.0.3.1"), []string{"192.0.2.255", "192.0.3.0", "192.0.3.1"})
+	assert.DeepEqual(t, expandAll(t, h, "2001:db8::/127"), []string{"2001:db8::", "2001:db8::1"})
+	assert.DeepEqual(t, expandAll(t, h, "2001:db8::ffff:ffff:ffff:ffff-2001:db8:0:1::"), []string{"2001:db8::ffff:ffff:ffff:ffff", "2001:db8:0:1::"})
+	assert.DeepEqual(t, expandAll(t, h, "192.0.2.1/32"), []string{"192.0.2.1"})
+
+	for _, s := range []string{"example.com", "192.0.2.1", "192.0.2.0/33", "192.0.2.9-192.0.2.1", "192.0.2.1-2001:db8::1", "a-b"} {
+		_, ok := parseIPRange(s)
+		assert.Assert(t, !ok, s)
+	}
+}
+
+func TestPermuteRange(t *testing.T) {
+	h := &RangeInputHandler{permute: true, rng: rand.New(rand.NewSource(1))}
+	ips := expandAll(t, h, "10.0.0.0/22")
+	seen := make(map[string]bool)
+	inOrder := true
+	for i, ip := range ips {
+		assert.Assert(t, !seen[ip], ip)
+		seen[ip] = true
+		r, _ := parseIPRange(ip + "/32")
+		if r.start.lo != uint64(0xffff0a000000)+uint64(i) {
+			inOrder = false
+		}
+	}
+	assert.Equal(t, len(seen), 1024)
+	assert.Assert(t, !inOrder)
+}
+
+func TestPermutationSizes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []uint64{1, 2, 3, 5, 17, 1000} {
+		p := newPermutation(n, rng)
+		seen := make(map[uint64]bool)
+		for i := uint64(0); i < n; i++ {
+			x := p.at(i)
+			assert.Assert(t, x < n)
+			seen[x] = true
+		}
+		assert.Equal(t, uint64(len(seen)), n)
+	}
+}
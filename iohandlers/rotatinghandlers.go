@@ -0,0 +1,261 @@
+package iohandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Placeholders that can be used in the file name template of a
+// RotatingOutputHandler
+const (
+	// sequence number of the file within its shard, starting at 00000
+	TemplateSeq = "{seq}"
+	// index of the shard, starting at 0
+	TemplateShard = "{shard}"
+	// UTC time at which the file was started, e.g., 20220131T235959Z
+	TemplateTime = "{time}"
+)
+
+// OutputRotation says when a RotatingOutputHandler moves on to a new file.
+// Limits that are zero are disabled.
+type OutputRotation struct {
+	// number of records per file
+	Records int
+	// number of bytes per file, before compression
+	Bytes int64
+	// maximum wall-clock time a file is written to
+	Interval time.Duration
+}
+
+// Enabled reports whether any limit is set
+func (r OutputRotation) Enabled() bool {
+	return r.Records > 0 || r.Bytes > 0 || r.Interval > 0
+}
+
+// RotatingOutputHandler writes results to a series of files named after a
+// template (e.g., results-{shard}-{seq}.json.gz), instead of one file. Files
+// are rolled over according to an OutputRotation, and results can be sharded
+// across several series of files by a hash of the name that was looked up, so
+// that all results for a name end up in the same shard.
+//
+// A file is written as <name>.tmp and only renamed to its final name once it
+// is complete, so that other programs can consume finished files while the
+// scan is still running. Files are started when the first record for them is
+// written, so no empty files are created.
+type RotatingOutputHandler struct {
+	template string
+	rotation OutputRotation
+	shards   int
+	// one of the Compression constants; defaults to CompressionAuto, which
+	// uses the extension of the template
+	Compression string
+	// format of the output; defaults to NewJSONEncoder
+	Encoder EncoderFactory
+}
+
+// NewRotatingOutputHandler creates a RotatingOutputHandler. The template must
+// contain {seq} if files are rotated by records or bytes, {seq} or {time} if
+// they are only rotated by interval, and {shard} if there is more than one
+// shard. {time} has a resolution of a second, so a file started in the same
+// second as an existing one gets a -1, -2, ... suffix after the time.
+func NewRotatingOutputHandler(template string, rotation OutputRotation, shards int) (*RotatingOutputHandler, error) {
+	if shards < 1 {
+		shards = 1
+	}
+	if template == "" || template == "-" {
+		return nil, errors.New("output can't be rotated or sharded when it is written to stdout")
+	}
+	// several files can be started in the same second, which {time} doesn't
+	// tell apart
+	if (rotation.Records > 0 || rotation.Bytes > 0) && !strings.Contains(template, TemplateSeq) {
+		return nil, fmt.Errorf("output file name must contain %s to rotate output by records or bytes", TemplateSeq)
+	}
+	if rotation.Enabled() && !strings.Contains(template, TemplateSeq) && !strings.Contains(template, TemplateTime) {
+		return nil, fmt.Errorf("output file name must contain %s or %s to rotate output", TemplateSeq, TemplateTime)
+	}
+	if shards > 1 && !strings.Contains(template, TemplateShard) {
+		return nil, fmt.Errorf("output file name must contain %s to shard output", TemplateShard)
+	}
+	return &RotatingOutputHandler{
+		template: template,
+		rotation: rotation,
+		shards:   shards,
+	}, nil
+}
+
+// outputChunk is a file of a RotatingOutputHandler that is being written to
+type outputChunk struct {
+	w    io.WriteCloser
+	enc  Encoder
+	path string
+	// bytes written by the encoder, before compression
+	counter *countingWriter
+	records int
+}
+
+type outputShard struct {
+	seq   int
+	chunk *outputChunk
+}
+
+func (h *RotatingOutputHandler) WriteResults(results <-chan string, wg *sync.WaitGroup) error {
+	defer (*wg).Done()
+
+	shards := make([]outputShard, h.shards)
+	var tick <-chan time.Time
+	if h.rotation.Interval > 0 {
+		ticker := time.NewTicker(h.rotation.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case n, ok := <-results:
+			if !ok {
+				for i := range shards {
+					h.finish(&shards[i])
+				}
+				return nil
+			}
+			i := shardOf(n, h.shards)
+			s := &shards[i]
+			if s.chunk == nil {
+				h.start(s, i)
+			}
+			if err := s.chunk.enc.Encode(n); err != nil {
+				log.Fatalf("unable to write output: %v", err)
+			}
+			s.chunk.records++
+			if (h.rotation.Records > 0 && s.chunk.records >= h.rotation.Records) ||
+				(h.rotation.Bytes > 0 && s.chunk.counter.count >= h.rotation.Bytes) {
+				h.finish(s)
+			}
+		case <-tick:
+			for i := range shards {
+				h.finish(&shards[i])
+			}
+		}
+	}
+}
+
+// fileName fills in the template for the seq'th file of shard. A dup greater
+// than zero is added to the time, to tell apart files started in the same
+// second.
+func (h *RotatingOutputHandler) fileName(shard, seq int, t time.Time, dup int) string {
+	ts := t.UTC().Format("20060102T150405Z")
+	if dup > 0 {
+		ts += fmt.Sprintf("-%d", dup)
+	}
+	return strings.NewReplacer(
+		TemplateSeq, fmt.Sprintf("%05d", seq),
+		TemplateShard, fmt.Sprintf("%d", shard),
+		TemplateTime, ts,
+	).Replace(h.template)
+}
+
+func (h *RotatingOutputHandler) start(s *outputShard, shard int) {
+	now := time.Now()
+	path := h.fileName(shard, s.seq, now, 0)
+	if !strings.Contains(h.template, TemplateSeq) {
+		// don't replace a file started earlier in the same second
+		for dup := 1; fileExists(path); dup++ {
+			path = h.fileName(shard, s.seq, now, dup)
+		}
+	}
+	// the compression is based on the final name rather than the .tmp one
+	w, err := createOutput(path+".tmp", outputCompression(path, h.Compression), nil)
+	if err != nil {
+		log.Fatalf("unable to open output file: %v", err)
+	}
+	counter := &countingWriter{w: w}
+	enc, err := newEncoder(h.Encoder, counter)
+	if err != nil {
+		log.Fatalf("unable to set up output format: %v", err)
+	}
+	s.chunk = &outputChunk{w: w, enc: enc, path: path, counter: counter}
+	s.seq++
+}
+
+// finish closes the current file of s, if any, and moves it into place
+func (h *RotatingOutputHandler) finish(s *outputShard) {
+	if s.chunk == nil {
+		return
+	}
+	if err := s.chunk.enc.Close(); err != nil {
+		log.Fatalf("unable to write output: %v", err)
+	}
+	if err := s.chunk.w.Close(); err != nil {
+		log.Fatalf("unable to write output file: %v", err)
+	}
+	if err := os.Rename(s.chunk.path+".tmp", s.chunk.path); err != nil {
+		log.Fatalf("unable to rename output file: %v", err)
+	}
+	s.chunk = nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// shardOf returns the shard of a JSON result, based on a hash of its name.
+// Results without a name all go to the first shard.
+func shardOf(line string, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	name := resultName(line)
+	if name == "" {
+		return 0
+	}
+	f := fnv.New32a()
+	f.Write([]byte(strings.ToLower(strings.TrimSuffix(name, "."))))
+	return int(f.Sum32() % uint32(shards))
+}
+
+// resultName returns the name of a JSON result, scanning it only up to its
+// name, which comes near the start of results
+func resultName(line string) string {
+	dec := json.NewDecoder(strings.NewReader(line))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return ""
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if key == "name" {
+			name, _ := dec.Token()
+			s, _ := name.(string)
+			return s
+		}
+		// skip the value, however deeply it is nested
+		depth := 0
+		for {
+			t, err := dec.Token()
+			if err != nil {
+				return ""
+			}
+			switch t {
+			case json.Delim('{'), json.Delim('['):
+				depth++
+			case json.Delim('}'), json.Delim(']'):
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package iohandlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func writeAll(t *testing.T, h *RotatingOutputHandler, lines []string) {
+	results := make(chan string)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go h.WriteResults(results, &wg)
+	for _, l := range lines {
+		results <- l
+	}
+	close(results)
+	wg.Wait()
+}
+
+func readDir(t *testing.T, dir string) map[string][]string {
+	files, err := ioutil.ReadDir(dir)
+	assert.NilError(t, err)
+	contents := make(map[string][]string)
+	for _, f := range files {
+		var read int64
+		r, _, err := openInput(filepath.Join(dir, f.Name()), CompressionAuto, &read)
+		assert.NilError(t, err)
+		data, err := ioutil.ReadAll(r)
+		assert.NilError(t, err)
+		r.Close()
+		contents[f.Name()] = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	return contents
+}
+
+func TestRotateByRecords(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewRotatingOutputHandler(filepath.Join(dir, "out-{seq}.json.gz"), OutputRotation{Records: 2}, 1)
+	assert.NilError(t, err)
+	writeAll(t, h, []string{`{"name":"a"}`, `{"name":"b"}`, `{"name":"c"}`})
+	assert.DeepEqual(t, readDir(t, dir), map[string][]string{
+		"out-00000.json.gz": {`{"name":"a"}`, `{"name":"b"}`},
+		"out-00001.json.gz": {`{"name":"c"}`},
+	})
+}
+
+func TestRotateByBytes(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewRotatingOutputHandler(filepath.Join(dir, "out-{seq}.json"), OutputRotation{Bytes: 20}, 1)
+	assert.NilError(t, err)
+	writeAll(t, h, []string{`{"name":"a"}`, `{"name":"b"}`, `{"name":"c"}`})
+	assert.Equal(t, len(readDir(t, dir)), 2)
+}
+
+func TestShardByName(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewRotatingOutputHandler(filepath.Join(dir, "out-{shard}.json"), OutputRotation{}, 4)
+	assert.NilError(t, err)
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf(`{"name":"example%d.com"}`, i))
+	}
+	// the same name always ends up in the same shard
+	lines = append(lines, `{"name":"EXAMPLE1.com."}`)
+	writeAll(t, h, lines)
+	contents := readDir(t, dir)
+	assert.Equal(t, len(contents), 4)
+	var all []string
+	for _, c := range contents {
+		all = append(all, c...)
+		for _, l := range c {
+			if l == `{"name":"example1.com"}` {
+				assert.Equal(t, c[len(c)-1], `{"name":"EXAMPLE1.com."}`)
+			}
+		}
+	}
+	sort.Strings(all)
+	sort.Strings(lines)
+	assert.DeepEqual(t, all, lines)
+}
+
+func TestResultName(t *testing.T) {
+	for line, name := range map[string]string{
+		`{"name":"example.com","data":{"answers":[]}}`:                                      "example.com",
+		`{"altered_name":"a.com","results":{"A":{"data":{"name":"b.com"}}},"name":"c.com"}`: "c.com",
+		`{"data":[{"name":"b.com"},[1,{"x":[]}]],"status":"NOERROR"}`:                       "",
+		`{"name":1}`: "",
+		`["name"]`:   "",
+		`{"name":`:   "",
+		`not json`:   "",
+	} {
+		assert.Equal(t, resultName(line), name, line)
+	}
+}
+
+func TestRotatingTemplateValidation(t *testing.T) {
+	_, err := NewRotatingOutputHandler("out.json", OutputRotation{Records: 10}, 1)
+	assert.ErrorContains(t, err, "{seq}")
+	_, err = NewRotatingOutputHandler("out-{seq}.json", OutputRotation{}, 2)
+	assert.ErrorContains(t, err, "{shard}")
+	_, err = NewRotatingOutputHandler("-", OutputRotation{Records: 10}, 1)
+	assert.ErrorContains(t, err, "stdout")
+	_, err = NewRotatingOutputHandler("out-{time}.json", OutputRotation{Interval: 1}, 1)
+	assert.NilError(t, err)
+	// files rotated by records or bytes can start within the same second
+	_, err = NewRotatingOutputHandler("out-{time}.json", OutputRotation{Records: 1}, 1)
+	assert.ErrorContains(t, err, "{seq}")
+	_, err = NewRotatingOutputHandler("out-{time}.json", OutputRotation{Bytes: 100, Interval: 1}, 1)
+	assert.ErrorContains(t, err, "{seq}")
+	_, err = NewRotatingOutputHandler("out-{time}-{seq}.json", OutputRotation{Records: 1}, 1)
+	assert.NilError(t, err)
+}
+
+func TestRotateByTimeInSameSecond(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewRotatingOutputHandler(filepath.Join(dir, "out-{time}.json"), OutputRotation{Interval: 1}, 1)
+	assert.NilError(t, err)
+	// an interval this short starts several files in the same second
+	var s outputShard
+	for _, n := range []string{`{"name":"a"}`, `{"name":"b"}`, `{"name":"c"}`} {
+		h.start(&s, 0)
+		assert.NilError(t, s.chunk.enc.Encode(n))
+		h.finish(&s)
+	}
+	contents := readDir(t, dir)
+	assert.Equal(t, len(contents), 3)
+	var all []string
+	for _, c := range contents {
+		all = append(all, c...)
+	}
+	sort.Strings(all)
+	assert.DeepEqual(t, all, []string{`{"name":"a"}`, `{"name":"b"}`, `{"name":"c"}`})
+}
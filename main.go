@@ -17,11 +17,18 @@ import (
 	"github.com/zmap/zdns/cmd"
 	_ "github.com/zmap/zdns/pkg/alookup"
 	_ "github.com/zmap/zdns/pkg/axfr"
+	_ "github.com/zmap/zdns/pkg/bimi"
 	_ "github.com/zmap/zdns/pkg/bindversion"
+	_ "github.com/zmap/zdns/pkg/caalookup"
+	_ "github.com/zmap/zdns/pkg/dane"
+	_ "github.com/zmap/zdns/pkg/dkim"
 	_ "github.com/zmap/zdns/pkg/dmarc"
+	_ "github.com/zmap/zdns/pkg/emailsec"
 	_ "github.com/zmap/zdns/pkg/miekg"
+	_ "github.com/zmap/zdns/pkg/mtasts"
 	_ "github.com/zmap/zdns/pkg/mxlookup"
 	_ "github.com/zmap/zdns/pkg/nslookup"
+	_ "github.com/zmap/zdns/pkg/soacheck"
 	_ "github.com/zmap/zdns/pkg/spf"
 )
 
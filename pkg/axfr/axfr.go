@@ -15,15 +15,20 @@
 package axfr
 
 import (
+	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 
 	"github.com/zmap/dns"
 	"github.com/zmap/go-iptree/blacklist"
+	"github.com/zmap/zdns/internal/metrics"
 	"github.com/zmap/zdns/pkg/miekg"
 	"github.com/zmap/zdns/pkg/nslookup"
 	"github.com/zmap/zdns/pkg/zdns"
@@ -41,6 +46,8 @@ type AXFRServerResult struct {
 	Status  string        `json:"status" groups:"short,normal,long,trace"`
 	Error   string        `json:"error,omitempty" groups:"short,normal,long,trace"`
 	Records []interface{} `json:"records,omitempty" groups:"short,normal,long,trace"`
+	// the zone file the transfer was written to, with --axfr-zone-dir
+	ZoneFile string `json:"zone_file,omitempty" groups:"short,normal,long,trace"`
 }
 
 type AXFRResult struct {
@@ -64,6 +71,7 @@ func (s *Lookup) DoAXFR(name, server string) AXFRServerResult {
 			return retv
 		} else if blacklisted {
 			s.Factory.Factory.BlMu.Unlock()
+			metrics.BlacklistHits.Inc()
 			retv.Status = "ERROR"
 			retv.Error = "blacklisted"
 			return retv
@@ -78,6 +86,7 @@ func (s *Lookup) DoAXFR(name, server string) AXFRServerResult {
 		retv.Error = err.Error()
 		return retv
 	} else {
+		var rrs []dns.RR
 		for ex := range a {
 			if ex.Error != nil {
 				retv.Status = "ERROR"
@@ -89,12 +98,70 @@ func (s *Lookup) DoAXFR(name, server string) AXFRServerResult {
 					ans := miekg.ParseAnswer(rr)
 					retv.Records = append(retv.Records, ans)
 				}
+				rrs = append(rrs, ex.RR...)
 			}
 		}
+		if s.Factory.Factory.ZoneDir != "" && retv.Status == "NOERROR" {
+			path, err := WriteZoneFile(s.Factory.Factory.ZoneDir, dotName(name), server, rrs)
+			if err != nil {
+				log.Warnf("unable to write zone file for %s from %s: %v", name, server, err)
+			}
+			retv.ZoneFile = path
+		}
 	}
 	return retv
 }
 
+// ZoneFileName returns the name of the zone file of zone as transferred from
+// server, with any characters that could escape the directory replaced
+func ZoneFileName(zone, server string) string {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if zone == "" {
+		zone = "root"
+	}
+	safe := func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == ':':
+			return r
+		}
+		return '_'
+	}
+	return strings.Map(safe, zone) + "_" + strings.Map(safe, server) + ".zone"
+}
+
+// WriteZoneFile writes the records of a transfer of zone from server to dir in
+// RFC 1035 master file format, and returns the path of the file. The file is
+// replaced atomically, so that a file that exists is always complete.
+func WriteZoneFile(dir, zone, server string, rrs []dns.RR) (string, error) {
+	// a transfer ends with the SOA record it starts with
+	if len(rrs) > 1 && rrs[0].Header().Rrtype == dns.TypeSOA && dns.IsDuplicate(rrs[0], rrs[len(rrs)-1]) {
+		rrs = rrs[:len(rrs)-1]
+	}
+	path := filepath.Join(dir, ZoneFileName(zone, server))
+	f, err := os.CreateTemp(dir, ".zone-*")
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(f, "; zone %s transferred from %s at %s\n", dns.Fqdn(zone), server, time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "$ORIGIN %s\n", dns.Fqdn(zone))
+	for _, rr := range rrs {
+		fmt.Fprintln(f, rr.String())
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return path, nil
+}
+
 func (s *Lookup) DoLookup(name, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
 	var retv AXFRResult
 	if nameServer == "" {
@@ -134,6 +201,8 @@ type GlobalLookupFactory struct {
 	BlacklistPath string
 	Blacklist     *blacklist.Blacklist
 	BlMu          sync.Mutex
+	// directory to write successful transfers to as zone files
+	ZoneDir string
 }
 
 // Command-line Help Documentation. This is the descriptive text what is
@@ -149,6 +218,10 @@ func (s *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
 	if err != nil {
 		panic(err)
 	}
+	s.ZoneDir, err = f.GetString("axfr-zone-dir")
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (s *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
@@ -171,6 +244,11 @@ func (s *GlobalLookupFactory) Initialize(c *zdns.GlobalConf) error {
 	if c.IterativeResolution == true {
 		log.Fatal("AXFR module does not support iterative resolution")
 	}
+	if s.ZoneDir != "" {
+		if err := os.MkdirAll(s.ZoneDir, 0755); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
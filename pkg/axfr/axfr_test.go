@@ -0,0 +1,56 @@
+package axfr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zmap/dns"
+	"gotest.tools/v3/assert"
+)
+
+func TestZoneFileName(t *testing.T) {
+	assert.Equal(t, ZoneFileName("Example.COM.", "192.0.2.53"), "example.com_192.0.2.53.zone")
+	assert.Equal(t, ZoneFileName(".", "2001:db8::53"), "root_2001:db8::53.zone")
+	assert.Equal(t, ZoneFileName("../etc/x", "192.0.2.53"), ".._etc_x_192.0.2.53.zone")
+}
+
+func TestWriteZoneFile(t *testing.T) {
+	var rrs []dns.RR
+	for _, s := range []string{
+		"example.com. 3600 IN SOA ns.example.com. admin.example.com. 1 7200 600 86400 300",
+		"example.com. 3600 IN NS ns.example.com.",
+		"www.example.com. 3600 IN A 192.0.2.1",
+		"example.com. 3600 IN SOA ns.example.com. admin.example.com. 1 7200 600 86400 300",
+	} {
+		rr, err := dns.NewRR(s)
+		assert.NilError(t, err)
+		rrs = append(rrs, rr)
+	}
+	dir := t.TempDir()
+	path, err := WriteZoneFile(dir, "example.com.", "192.0.2.53", rrs)
+	assert.NilError(t, err)
+	assert.Equal(t, path, filepath.Join(dir, "example.com_192.0.2.53.zone"))
+
+	b, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Assert(t, strings.HasPrefix(lines[0], "; zone example.com. transferred from 192.0.2.53 at "))
+	assert.Equal(t, lines[1], "$ORIGIN example.com.")
+	// the closing SOA is left out
+	assert.Equal(t, len(lines), 5)
+
+	// the file can be read back as a zone
+	zp := dns.NewZoneParser(strings.NewReader(string(b)), "", "")
+	var n int
+	for _, ok := zp.Next(); ok; _, ok = zp.Next() {
+		n++
+	}
+	assert.NilError(t, zp.Err())
+	assert.Equal(t, n, 3)
+
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+}
@@ -0,0 +1,216 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bimi
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/dmarc"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+const bimiPrefixRegexp = "(?i)^v[\t ]*=[\t ]*BIMI1[\t ]*(;|$)"
+
+const bimiLabel = "._bimi."
+
+// result to be returned by scan of host
+type Result struct {
+	Bimi     string `json:"bimi,omitempty" groups:"short,normal,long,trace"`
+	Selector string `json:"selector,omitempty" groups:"short,normal,long,trace"`
+	// the domain the record was found for, which is the organizational
+	// domain of the name if the name has no record of its own
+	Domain   string `json:"domain,omitempty" groups:"short,normal,long,trace"`
+	Fallback bool   `json:"organizational_domain_fallback,omitempty" groups:"short,normal,long,trace"`
+	Tags     *Tags  `json:"tags,omitempty" groups:"short,normal,long,trace"`
+	// the domain declines to publish an indicator (empty l and a)
+	Declined bool `json:"declined,omitempty" groups:"short,normal,long,trace"`
+	// the DMARC policy of the name, which has to be enforced for receivers to
+	// show the indicator
+	DMARC  *DMARCPolicy `json:"dmarc,omitempty" groups:"short,normal,long,trace"`
+	Errors []string     `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// DMARCPolicy is the DMARC policy that applies to the name
+type DMARCPolicy struct {
+	Domain string `json:"domain,omitempty" groups:"short,normal,long,trace"`
+	// p, or sp for a name that falls back to its organizational domain
+	Policy string `json:"policy,omitempty" groups:"short,normal,long,trace"`
+	Pct    int    `json:"pct" groups:"short,normal,long,trace"`
+	// the policy is quarantine or reject, for all messages
+	Sufficient bool   `json:"sufficient" groups:"short,normal,long,trace"`
+	Error      string `json:"error,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+var errNoRecord = errors.New("no such TXT record found")
+
+// DoLookup looks up the BIMI record of name, which is either a domain, whose
+// record is looked up for --bimi-selector, or a <selector>._bimi.<domain>
+// name, falling back to its organizational domain. The DMARC policy of the
+// name is checked for names with a record.
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	var trace zdns.Trace
+	query := func(qname string) ([]interface{}, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: dns.TypeTXT, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		return answers(res), status, err
+	}
+	selector := s.Factory.Factory.Selector
+	domain := strings.TrimSuffix(name, ".")
+	if i := strings.Index(strings.ToLower(domain), bimiLabel); i >= 0 {
+		selector, domain = domain[:i], domain[i+len(bimiLabel):]
+	}
+	res := Result{Selector: selector, Domain: domain}
+	records, status, err := s.findRecords(query, selector+bimiLabel+domain)
+	if len(records) == 0 && (status == zdns.STATUS_NOERROR || status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER) {
+		if org := dmarc.OrganizationalDomain(domain); org != "" && !strings.EqualFold(org, domain) {
+			records, status, err = s.findRecords(query, selector+bimiLabel+org)
+			res.Domain, res.Fallback = org, true
+		}
+	}
+	if status != zdns.STATUS_NOERROR {
+		return nil, trace, status, err
+	}
+	if len(records) == 0 {
+		return nil, trace, zdns.STATUS_NO_RECORD, errNoRecord
+	}
+	res.Bimi = records[0]
+	if len(records) > 1 {
+		res.Errors = append(res.Errors, "more than one BIMI record")
+	}
+	tags, errs := ParseRecord(strings.ReplaceAll(res.Bimi, "\n", ""))
+	res.Tags = tags
+	res.Errors = append(res.Errors, errs...)
+	res.Declined = tags.L == "" && tags.A == ""
+	if !res.Declined {
+		// the policy of the name itself, not of the domain of the record
+		res.DMARC = checkDMARC(query, domain)
+		if !res.DMARC.Sufficient {
+			res.Errors = append(res.Errors, "DMARC policy is not quarantine or reject at pct=100")
+		}
+	}
+	return res, trace, zdns.STATUS_NOERROR, nil
+}
+
+// findRecords returns the BIMI records among the TXT records of name
+func (s *Lookup) findRecords(query dmarc.QueryFunc, name string) ([]string, zdns.Status, error) {
+	answers, status, err := query(name)
+	if status != zdns.STATUS_NOERROR {
+		return nil, status, err
+	}
+	var records []string
+	for _, a := range answers {
+		if ans, ok := a.(miekg.Answer); ok && s.Factory.PrefixRegexp.MatchString(ans.Answer) {
+			records = append(records, ans.Answer)
+		}
+	}
+	return records, status, err
+}
+
+// checkDMARC looks up the DMARC policy of domain, which has to be quarantine
+// or reject, for all messages, for receivers to show the indicator
+func checkDMARC(query dmarc.QueryFunc, domain string) *DMARCPolicy {
+	res, status, err := dmarc.Find(query, domain)
+	if status != zdns.STATUS_NOERROR {
+		policy := &DMARCPolicy{Error: string(status)}
+		if err != nil {
+			policy.Error += ": " + err.Error()
+		}
+		return policy
+	}
+	policy := &DMARCPolicy{Domain: res.Domain, Policy: res.Tags.P, Pct: res.Tags.Pct}
+	if res.Fallback {
+		policy.Policy = res.Tags.SP
+	}
+	for _, e := range res.Errors {
+		if e == "more than one DMARC record" {
+			// receivers don't apply any policy then
+			policy.Error = e
+		}
+	}
+	policy.Sufficient = policy.Error == "" && (policy.Policy == "quarantine" || policy.Policy == "reject") && policy.Pct == 100
+	return policy
+}
+
+func answers(res interface{}) []interface{} {
+	r, _ := res.(miekg.Result)
+	return r.Answers
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeTXT, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+func (rlf *RoutineLookupFactory) InitPrefixRegexp() {
+	rlf.PrefixRegexp = regexp.MustCompile(bimiPrefixRegexp)
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	Selector string
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	glf.Selector, err = f.GetString("bimi-selector")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.InitPrefixRegexp()
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	s.Selector = "default"
+	zdns.RegisterLookup("BIMI", s)
+}
@@ -0,0 +1,146 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bimi
+
+import (
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+var mockResults = make(map[string]miekg.Result)
+var queries []QueryRecord
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if res, ok := mockResults[question.Name]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{}, nil, zdns.STATUS_NXDOMAIN, nil
+	}
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[string]miekg.Result)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+	glf.Selector = "default"
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+	rlf.InitPrefixRegexp()
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func txt(name string, records ...string) miekg.Result {
+	var res miekg.Result
+	for _, record := range records {
+		res.Answers = append(res.Answers, miekg.Answer{Name: name, Type: "TXT", Answer: record})
+	}
+	return res
+}
+
+func TestBimiLookup_Valid(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["default._bimi.zdns-testing.com"] = txt("default._bimi.zdns-testing.com", "some TXT record", "v=BIMI1; l=https://zdns-testing.com/logo.svg; a=https://zdns-testing.com/vmc.pem")
+	mockResults["_dmarc.zdns-testing.com"] = txt("_dmarc.zdns-testing.com", "v=DMARC1; p=reject")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, queries[0].Name, "default._bimi.zdns-testing.com")
+	assert.Equal(t, queries[0].Type, dns.TypeTXT)
+	assert.Equal(t, queries[1].Name, "_dmarc.zdns-testing.com")
+	r := res.(Result)
+	assert.Equal(t, r.Selector, "default")
+	assert.Equal(t, r.Domain, "zdns-testing.com")
+	assert.DeepEqual(t, r.Tags, &Tags{V: "BIMI1", L: "https://zdns-testing.com/logo.svg", A: "https://zdns-testing.com/vmc.pem"})
+	assert.DeepEqual(t, r.DMARC, &DMARCPolicy{Domain: "zdns-testing.com", Policy: "reject", Pct: 100, Sufficient: true})
+	assert.Equal(t, len(r.Errors), 0)
+}
+
+func TestBimiLookup_WeakDMARC(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["default._bimi.zdns-testing.com"] = txt("default._bimi.zdns-testing.com", "v=BIMI1; l=https://zdns-testing.com/logo.svg")
+	mockResults["_dmarc.zdns-testing.com"] = txt("_dmarc.zdns-testing.com", "v=DMARC1; p=quarantine; pct=50")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.DeepEqual(t, r.DMARC, &DMARCPolicy{Domain: "zdns-testing.com", Policy: "quarantine", Pct: 50})
+	assert.DeepEqual(t, r.Errors, []string{"DMARC policy is not quarantine or reject at pct=100"})
+}
+
+func TestBimiLookup_Fallback(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["brand._bimi.zdns-testing.com"] = txt("brand._bimi.zdns-testing.com", "v=BIMI1; l=http://zdns-testing.com/logo.svg")
+	mockResults["_dmarc.zdns-testing.com"] = txt("_dmarc.zdns-testing.com", "v=DMARC1; p=reject; sp=none")
+	res, _, status, _ := l.DoLookup("brand._bimi.mail.zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, r.Selector, "brand")
+	assert.Equal(t, r.Domain, "zdns-testing.com")
+	assert.Equal(t, r.Fallback, true)
+	// the subdomain policy applies to mail.zdns-testing.com
+	assert.DeepEqual(t, r.DMARC, &DMARCPolicy{Domain: "zdns-testing.com", Policy: "none", Pct: 100})
+	assert.DeepEqual(t, r.Errors, []string{
+		`invalid l URL "http://zdns-testing.com/logo.svg": not an HTTPS URL`,
+		"DMARC policy is not quarantine or reject at pct=100",
+	})
+}
+
+func TestBimiLookup_Declined(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["default._bimi.zdns-testing.com"] = txt("default._bimi.zdns-testing.com", "v=BIMI1; l=; a=;")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, len(queries), 1)
+	r := res.(Result)
+	assert.Equal(t, r.Declined, true)
+	assert.Assert(t, r.DMARC == nil)
+	assert.Equal(t, len(r.Errors), 0)
+}
+
+func TestBimiLookup_NoRecord(t *testing.T) {
+	_, _, _, l := InitTest()
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NXDOMAIN, status)
+	assert.Equal(t, res, nil)
+
+	mockResults["default._bimi.zdns-testing.com"] = txt("default._bimi.zdns-testing.com", "some TXT record")
+	res, _, status, _ = l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NO_RECORD, status)
+	assert.Equal(t, res, nil)
+}
+
+func TestParseRecord(t *testing.T) {
+	tags, errs := ParseRecord("v=BIMI1; a=https://zdns-testing.com/vmc.pem; a=https://zdns-testing.com/other.pem")
+	assert.Equal(t, tags.A, "https://zdns-testing.com/vmc.pem")
+	assert.DeepEqual(t, errs, []string{"more than one a tag", "missing l tag"})
+}
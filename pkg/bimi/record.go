@@ -0,0 +1,99 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bimi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Tags are the tags of a BIMI assertion record
+type Tags struct {
+	V string `json:"v" groups:"short,normal,long,trace"`
+	// the URL of the SVG indicator
+	L string `json:"l" groups:"short,normal,long,trace"`
+	// the URL of the evidence document, e.g., a Verified Mark Certificate
+	A string `json:"a,omitempty" groups:"short,normal,long,trace"`
+}
+
+// ParseRecord parses the tags of a BIMI record, which starts with v=BIMI1.
+// Problems that would make receivers ignore the record or some of its tags
+// are returned as errors, along with the tags that could be parsed.
+func ParseRecord(record string) (*Tags, []string) {
+	tags := &Tags{}
+	var errs []string
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+	seen := make(map[string]bool)
+	for i, part := range strings.Split(record, ";") {
+		part = strings.Trim(part, " \t")
+		if part == "" {
+			continue
+		}
+		eq := strings.IndexByte(part, '=')
+		if eq < 0 {
+			errorf("invalid tag %q", part)
+			continue
+		}
+		name, value := strings.ToLower(strings.Trim(part[:eq], " \t")), strings.Trim(part[eq+1:], " \t")
+		if i == 0 {
+			// the version has been checked by the prefix regexp
+			tags.V = value
+			continue
+		}
+		if seen[name] {
+			errorf("more than one %s tag", name)
+			continue
+		}
+		seen[name] = true
+		switch name {
+		case "v":
+			errorf("v tag is not first")
+		case "l", "a":
+			if value != "" {
+				if err := checkURL(value); err != nil {
+					errorf("invalid %s URL %q: %v", name, value, err)
+				}
+			}
+			if name == "l" {
+				tags.L = value
+			} else {
+				tags.A = value
+			}
+		}
+		// unknown tags are ignored
+	}
+	if !seen["l"] {
+		errorf("missing l tag")
+	}
+	return tags, errs
+}
+
+// checkURL checks that a BIMI URL is an absolute HTTPS URL
+func checkURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(u.Scheme, "https") {
+		return fmt.Errorf("not an HTTPS URL")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package caalookup
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// CNAMEs followed from a single name
+const maxCNAMEs = 8
+
+// result to be returned by scan of host
+type Result struct {
+	// the domain the relevant RRset was found at, which is empty if neither
+	// the name nor its ancestors have CAA records, and any CA may issue
+	Domain string `json:"domain,omitempty" groups:"short,normal,long,trace"`
+	// the CNAMEs followed from the domain to the RRset
+	CNAMEs     []string    `json:"cnames,omitempty" groups:"short,normal,long,trace"`
+	Properties []Property  `json:"properties,omitempty" groups:"short,normal,long,trace"`
+	Evaluation *Evaluation `json:"evaluation,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+// DoLookup finds the relevant CAA RRset of name, climbing from the name
+// toward the root (RFC 8659, section 3), and evaluates it for --ca-domain
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	var res Result
+	var trace zdns.Trace
+	domain := strings.ToLower(strings.TrimSuffix(name, "."))
+	// the root is never looked up
+	for domain != "" {
+		records, cnames, t, status, err := s.lookupCAA(domain, nameServer)
+		trace = append(trace, t...)
+		if status != zdns.STATUS_NOERROR && status != zdns.STATUS_NXDOMAIN && status != zdns.STATUS_NO_ANSWER {
+			// CAs must not issue when the lookup fails
+			return nil, trace, status, err
+		}
+		if len(records) > 0 {
+			res.Domain, res.CNAMEs = domain, cnames
+			for _, r := range records {
+				res.Properties = append(res.Properties, ParseProperty(r))
+			}
+			break
+		}
+		if i := strings.IndexByte(domain, '.'); i >= 0 {
+			domain = domain[i+1:]
+		} else {
+			domain = ""
+		}
+	}
+	if ca := s.Factory.Factory.CADomain; ca != "" {
+		res.Evaluation = Evaluate(res.Properties, ca)
+	}
+	return res, trace, zdns.STATUS_NOERROR, nil
+}
+
+// lookupCAA looks up the CAA RRset of name, following CNAMEs if the resolver
+// doesn't
+func (s *Lookup) lookupCAA(name, nameServer string) ([]miekg.CAAAnswer, []string, zdns.Trace, zdns.Status, error) {
+	var trace zdns.Trace
+	var cnames []string
+	current := name
+	for {
+		queried := current
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: current, Type: dns.TypeCAA, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		if status != zdns.STATUS_NOERROR {
+			if len(cnames) > 0 && (status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER) {
+				// the target of a CNAME has no records
+				status = zdns.STATUS_NOERROR
+			}
+			return nil, cnames, trace, status, err
+		}
+		answers := answers(res)
+		for target := cnameTarget(answers, current); target != "" && len(cnames) < maxCNAMEs; target = cnameTarget(answers, current) {
+			cnames = append(cnames, target)
+			current = target
+		}
+		var records []miekg.CAAAnswer
+		for _, a := range answers {
+			if ans, ok := a.(miekg.CAAAnswer); ok {
+				records = append(records, ans)
+			}
+		}
+		if len(records) > 0 || current == queried || len(cnames) >= maxCNAMEs {
+			return records, cnames, trace, zdns.STATUS_NOERROR, nil
+		}
+	}
+}
+
+// cnameTarget returns the target of the CNAME of name among answers, if any
+func cnameTarget(answers []interface{}, name string) string {
+	for _, a := range answers {
+		if ans, ok := a.(miekg.Answer); ok && ans.Type == "CNAME" && strings.EqualFold(strings.TrimSuffix(ans.Name, "."), name) {
+			return strings.ToLower(strings.TrimSuffix(ans.Answer, "."))
+		}
+	}
+	return ""
+}
+
+func answers(res interface{}) []interface{} {
+	r, _ := res.(miekg.Result)
+	return r.Answers
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeCAA, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	CADomain string
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	glf.CADomain, err = f.GetString("ca-domain")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	zdns.RegisterLookup("CAALOOKUP", s)
+}
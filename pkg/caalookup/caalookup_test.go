@@ -0,0 +1,214 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package caalookup
+
+import (
+	"testing"
+
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+var mockResults = make(map[string]miekg.Result)
+var mockStatus = make(map[string]zdns.Status)
+var queries []QueryRecord
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if status, ok := mockStatus[question.Name]; ok {
+		return miekg.Result{}, nil, status, nil
+	}
+	if res, ok := mockResults[question.Name]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{}, nil, zdns.STATUS_NO_ANSWER, nil
+	}
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[string]miekg.Result)
+	mockStatus = make(map[string]zdns.Status)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func mock(name string, answers ...interface{}) {
+	mockResults[name] = miekg.Result{Answers: answers}
+}
+
+func caa(name string, flag uint8, tag, value string) miekg.CAAAnswer {
+	return miekg.CAAAnswer{Answer: miekg.Answer{Name: name, Type: "CAA"}, Flag: flag, Tag: tag, Value: value}
+}
+
+func cname(name, target string) miekg.Answer {
+	return miekg.Answer{Name: name, Type: "CNAME", Answer: target + "."}
+}
+
+func queried() []string {
+	var names []string
+	for _, q := range queries {
+		names = append(names, q.Name)
+	}
+	return names
+}
+
+func TestClimbToParent(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "letsencrypt.org"
+	mock("example.com", caa("example.com", 0, "issue", "letsencrypt.org"))
+	res, _, status, err := l.DoLookup("www.sub.example.com", "")
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "example.com")
+	assert.Equal(t, len(r.Properties), 1)
+	assert.Equal(t, r.Properties[0].Issuer, "letsencrypt.org")
+	assert.DeepEqual(t, r.Evaluation, &Evaluation{CA: "letsencrypt.org", Issue: true, IssueWild: true})
+	assert.DeepEqual(t, queried(), []string{"www.sub.example.com", "sub.example.com", "example.com"})
+}
+
+func TestNoRecords(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "letsencrypt.org"
+	mockStatus["www.example.com"] = zdns.STATUS_NXDOMAIN
+	res, _, status, err := l.DoLookup("www.example.com", "")
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "")
+	assert.Equal(t, len(r.Properties), 0)
+	assert.DeepEqual(t, r.Evaluation, &Evaluation{CA: "letsencrypt.org", Issue: true, IssueWild: true})
+	// the root isn't looked up
+	assert.DeepEqual(t, queried(), []string{"www.example.com", "example.com", "com"})
+}
+
+func TestNoEvaluationWithoutCA(t *testing.T) {
+	_, _, _, l := InitTest()
+	mock("example.com", caa("example.com", 0, "issue", "letsencrypt.org"))
+	res, _, _, _ := l.DoLookup("example.com", "")
+	assert.Assert(t, res.(Result).Evaluation == nil)
+}
+
+func TestFollowCNAME(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "pki.goog"
+	mock("www.example.com", cname("www.example.com", "cdn.example.net"))
+	mock("cdn.example.net", caa("cdn.example.net", 0, "issue", "pki.goog"))
+	// the parent of the name, not of the target, is the next to climb to
+	mock("example.net", caa("example.net", 0, "issue", ";"))
+	res, _, status, err := l.DoLookup("www.example.com", "")
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "www.example.com")
+	assert.DeepEqual(t, r.CNAMEs, []string{"cdn.example.net"})
+	assert.Equal(t, r.Properties[0].Issuer, "pki.goog")
+	assert.Assert(t, r.Evaluation.Issue)
+}
+
+func TestChasedCNAME(t *testing.T) {
+	_, _, _, l := InitTest()
+	mock("www.example.com", cname("www.example.com", "cdn.example.net"), caa("cdn.example.net", 0, "issue", "pki.goog"))
+	res, _, _, _ := l.DoLookup("www.example.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "www.example.com")
+	assert.DeepEqual(t, r.CNAMEs, []string{"cdn.example.net"})
+	assert.Equal(t, len(r.Properties), 1)
+	assert.DeepEqual(t, queried(), []string{"www.example.com"})
+}
+
+func TestCNAMETargetWithoutRecords(t *testing.T) {
+	_, _, _, l := InitTest()
+	mock("www.example.com", cname("www.example.com", "cdn.example.net"))
+	mock("example.com", caa("example.com", 0, "issue", "digicert.com"))
+	res, _, _, _ := l.DoLookup("www.example.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "example.com")
+	assert.DeepEqual(t, queried(), []string{"www.example.com", "cdn.example.net", "example.com"})
+}
+
+func TestIssueWild(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "letsencrypt.org"
+	mock("example.com",
+		caa("example.com", 0, "issue", "letsencrypt.org; validationmethods=dns-01"),
+		caa("example.com", 0, "issuewild", "digicert.com"))
+	res, _, _, _ := l.DoLookup("example.com", "")
+	r := res.(Result)
+	assert.DeepEqual(t, r.Properties[0].Parameters, map[string]string{"validationmethods": "dns-01"})
+	assert.DeepEqual(t, r.Evaluation, &Evaluation{CA: "letsencrypt.org", Issue: true, IssueWild: false})
+}
+
+func TestNoIssuer(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "letsencrypt.org"
+	mock("example.com", caa("example.com", 0, "issue", ";"), caa("example.com", 0, "issuewild", "LetsEncrypt.org"))
+	res, _, _, _ := l.DoLookup("example.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.Properties[0].Issuer, "")
+	assert.DeepEqual(t, r.Evaluation, &Evaluation{CA: "letsencrypt.org", Issue: false, IssueWild: true})
+}
+
+func TestUnknownCriticalTag(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.CADomain = "letsencrypt.org"
+	mock("example.com", caa("example.com", 0, "issue", "letsencrypt.org"), caa("example.com", 128, "future", "x"))
+	res, _, _, _ := l.DoLookup("example.com", "")
+	r := res.(Result)
+	assert.Assert(t, r.Properties[1].Critical)
+	assert.Equal(t, r.Properties[1].Error, `unknown critical tag "future"`)
+	assert.DeepEqual(t, r.Evaluation, &Evaluation{CA: "letsencrypt.org"})
+}
+
+func TestInvalidIssuer(t *testing.T) {
+	p := ParseProperty(caa("example.com", 0, "issue", "lets encrypt"))
+	assert.Equal(t, p.Error, `invalid issuer "lets encrypt"`)
+	assert.Assert(t, !Evaluate([]Property{p}, "lets encrypt").Issue)
+}
+
+func TestIodef(t *testing.T) {
+	p := ParseProperty(caa("example.com", 0, "iodef", "mailto:security@example.com"))
+	assert.Equal(t, p.Error, "")
+	p = ParseProperty(caa("example.com", 0, "iodef", "ftp://example.com/report"))
+	assert.Equal(t, p.Error, `unsupported scheme "ftp"`)
+}
+
+func TestLookupFailure(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockStatus["example.com"] = zdns.STATUS_SERVFAIL
+	mock("com", caa("com", 0, "issue", "letsencrypt.org"))
+	res, _, status, _ := l.DoLookup("www.example.com", "")
+	assert.Equal(t, status, zdns.STATUS_SERVFAIL)
+	assert.Assert(t, res == nil)
+}
@@ -0,0 +1,140 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package caalookup
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/zmap/zdns/pkg/miekg"
+)
+
+// Property is a CAA record (RFC 8659, section 4)
+type Property struct {
+	Flag uint8 `json:"flag" groups:"short,normal,long,trace"`
+	// the issuer critical flag: CAs must not issue if they don't know the
+	// tag
+	Critical bool   `json:"critical,omitempty" groups:"short,normal,long,trace"`
+	Tag      string `json:"tag" groups:"short,normal,long,trace"`
+	Value    string `json:"value" groups:"short,normal,long,trace"`
+	// the domain of the CA of issue and issuewild properties, which is empty
+	// if no CA may issue
+	Issuer     string            `json:"issuer,omitempty" groups:"short,normal,long,trace"`
+	Parameters map[string]string `json:"parameters,omitempty" groups:"short,normal,long,trace"`
+	Error      string            `json:"error,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Evaluation is whether a CA may issue certificates for the name
+type Evaluation struct {
+	CA        string `json:"ca" groups:"short,normal,long,trace"`
+	Issue     bool   `json:"issue" groups:"short,normal,long,trace"`
+	IssueWild bool   `json:"issuewild" groups:"short,normal,long,trace"`
+}
+
+// tags of the IANA registry, which CAs know whether or not they use them
+var knownTags = map[string]bool{
+	"issue": true, "issuewild": true, "iodef": true, "contactemail": true,
+	"contactphone": true, "issuevmc": true, "issuemail": true,
+}
+
+var (
+	issuerRegexp   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+	paramKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+// ParseProperty parses a CAA answer, and the issuer and parameters of issue
+// and issuewild properties
+func ParseProperty(ans miekg.CAAAnswer) Property {
+	p := Property{Flag: ans.Flag, Critical: ans.Flag&128 != 0, Tag: strings.ToLower(ans.Tag), Value: ans.Value}
+	switch p.Tag {
+	case "issue", "issuewild":
+		parts := strings.Split(p.Value, ";")
+		p.Issuer = strings.ToLower(strings.Trim(parts[0], " \t"))
+		if p.Issuer != "" && !issuerRegexp.MatchString(p.Issuer) {
+			// it matches no CA (RFC 8659, section 4.2)
+			p.Error = fmt.Sprintf("invalid issuer %q", p.Issuer)
+		}
+		for _, param := range parts[1:] {
+			param = strings.Trim(param, " \t")
+			if param == "" {
+				continue
+			}
+			eq := strings.IndexByte(param, '=')
+			if eq < 0 || !paramKeyRegexp.MatchString(param[:eq]) {
+				p.Error = fmt.Sprintf("invalid parameter %q", param)
+				continue
+			}
+			if p.Parameters == nil {
+				p.Parameters = make(map[string]string)
+			}
+			p.Parameters[param[:eq]] = param[eq+1:]
+		}
+	case "iodef":
+		u, err := url.Parse(p.Value)
+		if err != nil {
+			p.Error = fmt.Sprintf("invalid URL %q", p.Value)
+		} else if s := strings.ToLower(u.Scheme); s != "mailto" && s != "http" && s != "https" {
+			p.Error = fmt.Sprintf("unsupported scheme %q", u.Scheme)
+		}
+	default:
+		if p.Critical && !knownTags[p.Tag] {
+			p.Error = fmt.Sprintf("unknown critical tag %q", p.Tag)
+		}
+	}
+	return p
+}
+
+// Evaluate returns whether ca may issue certificates, and wildcard
+// certificates, given the relevant CAA RRset (RFC 8659, section 4)
+func Evaluate(properties []Property, ca string) *Evaluation {
+	ca = strings.ToLower(strings.TrimSuffix(ca, "."))
+	eval := &Evaluation{CA: ca}
+	var issue, issueWild []Property
+	for _, p := range properties {
+		switch p.Tag {
+		case "issue":
+			issue = append(issue, p)
+		case "issuewild":
+			issueWild = append(issueWild, p)
+		default:
+			if p.Critical && !knownTags[p.Tag] {
+				return eval
+			}
+		}
+	}
+	eval.Issue = authorized(issue, ca)
+	if len(issueWild) > 0 {
+		eval.IssueWild = authorized(issueWild, ca)
+	} else {
+		eval.IssueWild = eval.Issue
+	}
+	return eval
+}
+
+// authorized is whether properties, of the same tag, let ca issue. Without
+// properties, any CA may.
+func authorized(properties []Property, ca string) bool {
+	if len(properties) == 0 {
+		return true
+	}
+	for _, p := range properties {
+		if p.Error == "" && p.Issuer != "" && p.Issuer == ca {
+			return true
+		}
+	}
+	return false
+}
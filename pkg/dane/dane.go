@@ -0,0 +1,231 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dane
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/mxlookup"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// result to be returned by scan of host
+type Result struct {
+	// whether the MX RRset of the domain is DNSSEC-secure, for domains whose
+	// MX hosts are checked
+	MXSecure *bool `json:"mx_secure,omitempty" groups:"short,normal,long,trace"`
+	// the domain publishes a null MX (RFC 7505), and doesn't accept mail
+	NullMX    bool       `json:"null_mx,omitempty" groups:"short,normal,long,trace"`
+	Endpoints []Endpoint `json:"endpoints,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Endpoint is a service endpoint and its TLSA records
+type Endpoint struct {
+	Host  string `json:"host" groups:"short,normal,long,trace"`
+	Port  uint16 `json:"port" groups:"short,normal,long,trace"`
+	Proto string `json:"proto" groups:"short,normal,long,trace"`
+	// the TLSA owner name, _port._proto.host
+	Name       string       `json:"name" groups:"short,normal,long,trace"`
+	TLSA       []TLSARecord `json:"tlsa,omitempty" groups:"short,normal,long,trace"`
+	TLSASecure bool         `json:"tlsa_secure" groups:"short,normal,long,trace"`
+	// the A and AAAA records of the host
+	IPv4Addresses []string `json:"ipv4_addresses,omitempty" groups:"short,normal,long,trace"`
+	IPv6Addresses []string `json:"ipv6_addresses,omitempty" groups:"short,normal,long,trace"`
+	AddressSecure bool     `json:"address_secure" groups:"short,normal,long,trace"`
+	// clients can authenticate the endpoint with DANE: it has usable TLSA
+	// records, and both they and the addresses of the host are secure
+	Usable bool     `json:"usable" groups:"short,normal,long,trace"`
+	Errors []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+// QueryFunc looks up the records of type qtype at name, and whether the
+// answer is DNSSEC-secure. The resolver only sets the AD bit on answers it has
+// validated if the query sets the DO bit, so lookups need Options.DNSSEC.
+type QueryFunc func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error)
+
+// DoLookup looks up the TLSA records of the endpoint given as host:port:proto,
+// or of the SMTP endpoints of the MX hosts of a domain
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	s.Options.DNSSEC = true
+	var trace zdns.Trace
+	query := func(qname string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: qtype, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		r := result(res)
+		return r.Answers, r.Flags.Authenticated, status, err
+	}
+	var res Result
+	if strings.Contains(name, ":") {
+		host, port, proto, err := ParseEndpoint(name)
+		if err != nil {
+			return nil, trace, zdns.STATUS_ILLEGAL_INPUT, err
+		}
+		res.Endpoints = append(res.Endpoints, lookupEndpoint(query, host, port, proto))
+		return res, trace, zdns.STATUS_NOERROR, nil
+	}
+	domain := strings.ToLower(strings.TrimSuffix(name, "."))
+	exchanges, status, err := mxlookup.LookupExchanges(mxlookup.QueryFunc(query), domain)
+	if status != zdns.STATUS_NOERROR {
+		return nil, trace, status, err
+	}
+	res.MXSecure = &exchanges.Secure
+	res.NullMX = exchanges.NullMX
+	if exchanges.Implicit() {
+		res.Endpoints = append(res.Endpoints, lookupEndpoint(query, domain, 25, "tcp"))
+	}
+	for _, mx := range exchanges.Records {
+		host := strings.ToLower(strings.TrimSuffix(mx.Answer.Answer, "."))
+		res.Endpoints = append(res.Endpoints, lookupEndpoint(query, host, 25, "tcp"))
+	}
+	return res, trace, zdns.STATUS_NOERROR, nil
+}
+
+// ParseEndpoint parses an endpoint given as host:port:proto, or host:port for
+// TCP
+func ParseEndpoint(s string) (string, uint16, string, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return "", 0, "", errors.New("endpoint is not host:port:proto")
+	}
+	port, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, "", fmt.Errorf("invalid port %q", parts[1])
+	}
+	proto := "tcp"
+	if len(parts) == 3 {
+		proto = strings.ToLower(parts[2])
+	}
+	if proto != "tcp" && proto != "udp" && proto != "sctp" {
+		return "", 0, "", fmt.Errorf("invalid protocol %q", parts[2])
+	}
+	return strings.ToLower(strings.TrimSuffix(parts[0], ".")), uint16(port), proto, nil
+}
+
+// lookupEndpoint looks up the TLSA records and the addresses of an endpoint
+func lookupEndpoint(query QueryFunc, host string, port uint16, proto string) Endpoint {
+	ep := Endpoint{Host: host, Port: port, Proto: proto, Name: fmt.Sprintf("_%d._%s.%s", port, proto, host)}
+	errorf := func(format string, args ...interface{}) {
+		ep.Errors = append(ep.Errors, fmt.Sprintf(format, args...))
+	}
+	tlsa, secure, status, err := LookupTLSA(query, ep.Name, port == 25 && proto == "tcp")
+	if miekg.LookupFailed(status) {
+		errorf("TLSA lookup failed: %s", miekg.LookupError(status, err))
+	}
+	ep.TLSA, ep.TLSASecure = tlsa, secure
+	usable := 0
+	for _, rec := range tlsa {
+		if rec.Error == "" {
+			usable++
+		}
+	}
+	ep.AddressSecure = true
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		answers, secure, status, err := query(host, qtype)
+		if miekg.LookupFailed(status) {
+			errorf("%s lookup failed: %s", dns.TypeToString[qtype], miekg.LookupError(status, err))
+		}
+		ep.AddressSecure = ep.AddressSecure && secure
+		for _, a := range answers {
+			if ans, ok := a.(miekg.Answer); ok && ans.Type == dns.TypeToString[qtype] {
+				if qtype == dns.TypeA {
+					ep.IPv4Addresses = append(ep.IPv4Addresses, ans.Answer)
+				} else {
+					ep.IPv6Addresses = append(ep.IPv6Addresses, ans.Answer)
+				}
+			}
+		}
+	}
+	if len(ep.TLSA) > 0 {
+		if usable == 0 {
+			errorf("no usable TLSA records")
+		}
+		if !ep.TLSASecure {
+			errorf("TLSA records are not DNSSEC-secure")
+		}
+		if !ep.AddressSecure {
+			errorf("address records of %s are not DNSSEC-secure", host)
+		}
+	}
+	ep.Usable = usable > 0 && ep.TLSASecure && ep.AddressSecure
+	return ep
+}
+
+// LookupTLSA looks up the TLSA records at name with query, and whether they
+// are DNSSEC-secure. smtp is as for ParseTLSA.
+func LookupTLSA(query QueryFunc, name string, smtp bool) ([]TLSARecord, bool, zdns.Status, error) {
+	answers, secure, status, err := query(name, dns.TypeTLSA)
+	var records []TLSARecord
+	for _, a := range answers {
+		if ans, ok := a.(miekg.TLSAAnswer); ok {
+			records = append(records, ParseTLSA(ans, smtp))
+		}
+	}
+	return records, secure, status, err
+}
+
+func result(res interface{}) miekg.Result {
+	r, _ := res.(miekg.Result)
+	return r
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeTLSA, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	zdns.RegisterLookup("DANE", s)
+}
@@ -0,0 +1,186 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dane
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+type mockKey struct {
+	name  string
+	qtype uint16
+}
+
+var mockResults = make(map[mockKey]miekg.Result)
+var queries []QueryRecord
+
+// whether the answers of names without mock results are secure
+var mockSecure bool
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if res, ok := mockResults[mockKey{question.Name, question.Type}]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{Flags: miekg.DNSFlags{Authenticated: mockSecure}}, nil, zdns.STATUS_NO_ANSWER, nil
+	}
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[mockKey]miekg.Result)
+	mockSecure = true
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func mock(name string, qtype uint16, secure bool, answers ...interface{}) {
+	mockResults[mockKey{name, qtype}] = miekg.Result{Answers: answers, Flags: miekg.DNSFlags{Authenticated: secure}}
+}
+
+func tlsa(name string, usage, selector, matchingType uint8, certificate string) miekg.TLSAAnswer {
+	return miekg.TLSAAnswer{Answer: miekg.Answer{Name: name, Type: "TLSA"}, CertUsage: usage, Selector: selector, MatchingType: matchingType, Certificate: certificate}
+}
+
+func address(name, qtype, ip string) miekg.Answer {
+	return miekg.Answer{Name: name, Type: qtype, Answer: ip}
+}
+
+var sha256Digest = strings.Repeat("ab", 32)
+
+func TestDaneLookup_MX(t *testing.T) {
+	_, _, _, l := InitTest()
+	mock("zdns-testing.com", dns.TypeMX, true,
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "mx2.zdns-testing.com."}, Preference: 20},
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "mx1.zdns-testing.com."}, Preference: 10})
+	mock("_25._tcp.mx1.zdns-testing.com", dns.TypeTLSA, true,
+		tlsa("_25._tcp.mx1.zdns-testing.com", 3, 1, 1, sha256Digest),
+		tlsa("_25._tcp.mx1.zdns-testing.com", 1, 1, 1, sha256Digest))
+	mock("mx1.zdns-testing.com", dns.TypeA, true, address("mx1.zdns-testing.com", "A", "192.0.2.1"))
+	mock("_25._tcp.mx2.zdns-testing.com", dns.TypeTLSA, false, tlsa("_25._tcp.mx2.zdns-testing.com", 2, 0, 1, sha256Digest))
+	mock("mx2.zdns-testing.com", dns.TypeA, true, address("mx2.zdns-testing.com", "A", "192.0.2.2"))
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, queries[0].Type, dns.TypeMX)
+	assert.Equal(t, queries[1].Name, "_25._tcp.mx1.zdns-testing.com")
+	assert.Equal(t, queries[1].Type, dns.TypeTLSA)
+	r := res.(Result)
+	assert.Equal(t, *r.MXSecure, true)
+	assert.Equal(t, len(r.Endpoints), 2)
+
+	mx1 := r.Endpoints[0]
+	assert.Equal(t, mx1.Name, "_25._tcp.mx1.zdns-testing.com")
+	assert.DeepEqual(t, mx1.TLSA[0], TLSARecord{Usage: 3, UsageName: "DANE-EE", Selector: 1, SelectorName: "SPKI", MatchingType: 1, MatchingTypeName: "SHA2-256", Combination: "3 1 1", Certificate: sha256Digest})
+	assert.Equal(t, mx1.TLSA[1].Error, "PKIX-EE is not used for SMTP")
+	assert.DeepEqual(t, mx1.IPv4Addresses, []string{"192.0.2.1"})
+	assert.Equal(t, mx1.TLSASecure, true)
+	assert.Equal(t, mx1.AddressSecure, true)
+	assert.Equal(t, mx1.Usable, true)
+	assert.Equal(t, len(mx1.Errors), 0)
+
+	mx2 := r.Endpoints[1]
+	assert.Equal(t, mx2.TLSA[0].Combination, "2 0 1")
+	assert.Equal(t, mx2.TLSASecure, false)
+	assert.Equal(t, mx2.Usable, false)
+	assert.DeepEqual(t, mx2.Errors, []string{"TLSA records are not DNSSEC-secure"})
+}
+
+func TestDaneLookup_Endpoint(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockSecure = false
+	mock("_443._tcp.www.zdns-testing.com", dns.TypeTLSA, true, tlsa("_443._tcp.www.zdns-testing.com", 1, 0, 2, "00"))
+	res, _, status, _ := l.DoLookup("www.zdns-testing.com:443:tcp", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Assert(t, r.MXSecure == nil)
+	ep := r.Endpoints[0]
+	assert.Equal(t, ep.Host, "www.zdns-testing.com")
+	assert.Equal(t, ep.Port, uint16(443))
+	assert.Equal(t, ep.TLSA[0].Error, "SHA2-512 digest of 1 bytes")
+	assert.Equal(t, ep.AddressSecure, false)
+	assert.DeepEqual(t, ep.Errors, []string{"no usable TLSA records", "address records of www.zdns-testing.com are not DNSSEC-secure"})
+}
+
+func TestDaneLookup_NullMX(t *testing.T) {
+	_, _, _, l := InitTest()
+	mock("zdns-testing.com", dns.TypeMX, true, miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "."}})
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, res.(Result).NullMX, true)
+	assert.Equal(t, len(res.(Result).Endpoints), 0)
+}
+
+func TestDaneLookup_ImplicitMX(t *testing.T) {
+	_, _, _, l := InitTest()
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, res.(Result).Endpoints[0].Name, "_25._tcp.zdns-testing.com")
+}
+
+func TestLookupTLSA(t *testing.T) {
+	name := "_25._tcp.mx.zdns-testing.com"
+	records, secure, status, err := LookupTLSA(func(qname string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		assert.Equal(t, qname, name)
+		assert.Equal(t, qtype, dns.TypeTLSA)
+		return []interface{}{
+			miekg.Answer{Name: name, Type: "CNAME", Answer: "tlsa.zdns-testing.com."},
+			tlsa(name, 3, 1, 1, sha256Digest),
+			tlsa(name, 0, 0, 1, sha256Digest),
+		}, true, zdns.STATUS_NOERROR, nil
+	}, name, true)
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	assert.Equal(t, secure, true)
+	// only the TLSA answers, checked as for SMTP
+	assert.Equal(t, len(records), 2)
+	assert.Equal(t, records[0].Combination, "3 1 1")
+	assert.Equal(t, records[0].Error, "")
+	assert.Equal(t, records[1].Error, "PKIX-TA is not used for SMTP")
+}
+
+func TestParseEndpoint(t *testing.T) {
+	host, port, proto, err := ParseEndpoint("Mail.zdns-testing.com.:465")
+	assert.NilError(t, err)
+	assert.Equal(t, host, "mail.zdns-testing.com")
+	assert.Equal(t, port, uint16(465))
+	assert.Equal(t, proto, "tcp")
+	_, _, _, err = ParseEndpoint("zdns-testing.com:https:tcp")
+	assert.Error(t, err, `invalid port "https"`)
+	_, _, _, err = ParseEndpoint("zdns-testing.com:443:quic")
+	assert.Error(t, err, `invalid protocol "quic"`)
+}
@@ -0,0 +1,85 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dane
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zdns/pkg/miekg"
+)
+
+// TLSARecord is a TLSA record, with the mnemonics of its fields (RFC 7218)
+type TLSARecord struct {
+	Usage            uint8  `json:"usage" groups:"short,normal,long,trace"`
+	UsageName        string `json:"usage_name,omitempty" groups:"short,normal,long,trace"`
+	Selector         uint8  `json:"selector" groups:"short,normal,long,trace"`
+	SelectorName     string `json:"selector_name,omitempty" groups:"short,normal,long,trace"`
+	MatchingType     uint8  `json:"matching_type" groups:"short,normal,long,trace"`
+	MatchingTypeName string `json:"matching_type_name,omitempty" groups:"short,normal,long,trace"`
+	// e.g., "3 1 1"
+	Combination string `json:"combination" groups:"short,normal,long,trace"`
+	Certificate string `json:"certificate" groups:"short,normal,long,trace"`
+	// the record can't be used, and is ignored by clients
+	Error string `json:"error,omitempty" groups:"short,normal,long,trace"`
+}
+
+var (
+	usageNames        = []string{"PKIX-TA", "PKIX-EE", "DANE-TA", "DANE-EE"}
+	selectorNames     = []string{"Cert", "SPKI"}
+	matchingTypeNames = []string{"Full", "SHA2-256", "SHA2-512"}
+	// the length of the digests of the matching types
+	digestLengths = map[uint8]int{1: 32, 2: 64}
+)
+
+func name(names []string, v uint8) string {
+	if int(v) < len(names) {
+		return names[v]
+	}
+	return ""
+}
+
+// ParseTLSA returns the fields of a TLSA answer, and checks that clients can
+// use it. smtp is whether the record is for SMTP, which only uses DANE-TA
+// and DANE-EE (RFC 7672, section 3.1.3).
+func ParseTLSA(ans miekg.TLSAAnswer, smtp bool) TLSARecord {
+	rec := TLSARecord{
+		Usage:            ans.CertUsage,
+		UsageName:        name(usageNames, ans.CertUsage),
+		Selector:         ans.Selector,
+		SelectorName:     name(selectorNames, ans.Selector),
+		MatchingType:     ans.MatchingType,
+		MatchingTypeName: name(matchingTypeNames, ans.MatchingType),
+		Combination:      fmt.Sprintf("%d %d %d", ans.CertUsage, ans.Selector, ans.MatchingType),
+		Certificate:      strings.ToLower(ans.Certificate),
+	}
+	data, err := hex.DecodeString(rec.Certificate)
+	switch {
+	case rec.UsageName == "":
+		rec.Error = fmt.Sprintf("unknown usage %d", rec.Usage)
+	case rec.SelectorName == "":
+		rec.Error = fmt.Sprintf("unknown selector %d", rec.Selector)
+	case rec.MatchingTypeName == "":
+		rec.Error = fmt.Sprintf("unknown matching type %d", rec.MatchingType)
+	case err != nil:
+		rec.Error = "invalid certificate association data"
+	case digestLengths[rec.MatchingType] != 0 && len(data) != digestLengths[rec.MatchingType]:
+		rec.Error = fmt.Sprintf("%s digest of %d bytes", rec.MatchingTypeName, len(data))
+	case smtp && rec.Usage < 2:
+		rec.Error = rec.UsageName + " is not used for SMTP"
+	}
+	return rec
+}
@@ -0,0 +1,225 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dkim
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/internal/util"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// a key record starts with the version, which is optional, or has a public
+// key tag
+const dkimPrefixRegexp = "(?i)^[\t ]*v[\t ]*=[\t ]*DKIM1[\t ]*(;|$)|(^|;)[\t ]*p[\t ]*="
+
+const domainKeyLabel = "._domainkey."
+
+// CNAMEs followed to find a key, e.g., to the key of an email provider
+const maxCNAMEs = 8
+
+// DefaultSelectors are common selectors, looked up for names given without
+// one
+var DefaultSelectors = []string{
+	"default", "dkim", "mail", "email", "selector1", "selector2", "google",
+	"k1", "k2", "k3", "s1", "s2", "s1024", "s2048", "smtp", "mx", "key1", "key2",
+	"dk", "mandrill", "everlytickey1", "everlytickey2", "mxvault", "pm",
+	"protonmail", "protonmail2", "protonmail3", "fm1", "fm2", "fm3", "zendesk1",
+	"zendesk2", "cm", "sig1",
+}
+
+// result to be returned by scan of host
+type Result struct {
+	Keys []Key `json:"keys,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Key is the key record found for a selector
+type Key struct {
+	Selector string `json:"selector" groups:"short,normal,long,trace"`
+	Domain   string `json:"domain" groups:"short,normal,long,trace"`
+	// the CNAMEs followed from <selector>._domainkey.<domain>
+	CNAMEs  []string `json:"cnames,omitempty" groups:"short,normal,long,trace"`
+	Record  string   `json:"record" groups:"short,normal,long,trace"`
+	Tags    *Tags    `json:"tags,omitempty" groups:"short,normal,long,trace"`
+	KeyType string   `json:"key_type,omitempty" groups:"short,normal,long,trace"`
+	// the size of the modulus of RSA keys
+	KeyBits int  `json:"key_bits,omitempty" groups:"short,normal,long,trace"`
+	Revoked bool `json:"revoked,omitempty" groups:"short,normal,long,trace"`
+	// the domain is testing DKIM (t=y), so verifiers treat signatures as if
+	// unsigned
+	Testing bool     `json:"testing,omitempty" groups:"short,normal,long,trace"`
+	Errors  []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+// DoLookup looks up the DKIM keys of name, which is either a domain, whose
+// keys are looked up for each selector of --dkim-selectors, or a
+// <selector>._domainkey.<domain> name
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	selectors := s.Factory.Factory.Selectors
+	domain := name
+	if i := strings.Index(strings.ToLower(name), domainKeyLabel); i >= 0 {
+		selectors = []string{name[:i]}
+		domain = name[i+len(domainKeyLabel):]
+	}
+	var res Result
+	var trace zdns.Trace
+	status := zdns.STATUS_NO_RECORD
+	var err error
+	for _, selector := range selectors {
+		key, keyTrace, keyStatus, keyErr := s.lookupKey(selector, domain, nameServer)
+		trace = append(trace, keyTrace...)
+		if key != nil {
+			res.Keys = append(res.Keys, *key)
+			status, err = zdns.STATUS_NOERROR, nil
+		} else if len(res.Keys) == 0 && !miekg.SafeStatus(keyStatus) && keyStatus != zdns.STATUS_NXDOMAIN && keyStatus != zdns.STATUS_NO_ANSWER {
+			// report failures rather than the absence of keys
+			status, err = keyStatus, keyErr
+		}
+	}
+	if len(res.Keys) == 0 {
+		return nil, trace, status, err
+	}
+	return res, trace, status, err
+}
+
+// lookupKey looks up the key record of selector, following CNAMEs
+func (s *Lookup) lookupKey(selector, domain, nameServer string) (*Key, zdns.Trace, zdns.Status, error) {
+	var trace zdns.Trace
+	name := selector + domainKeyLabel + domain
+	var cnames []string
+	for {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: name, Type: dns.TypeTXT, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		// the CNAMEs in the answers, whether the resolver chased them or not
+		followed := len(cnames)
+		for target := cnameTarget(res, name); target != "" && len(cnames) < maxCNAMEs; target = cnameTarget(res, name) {
+			cnames = append(cnames, target)
+			name = target
+		}
+		record, recordStatus, _ := s.CheckTxtRecords(res, status, err)
+		if recordStatus == zdns.STATUS_NOERROR {
+			key := ParseKey(strings.ReplaceAll(record, "\n", ""))
+			key.Selector, key.Domain, key.CNAMEs, key.Record = selector, domain, cnames, record
+			return key, trace, status, nil
+		}
+		if status != zdns.STATUS_NOERROR {
+			return nil, trace, status, err
+		}
+		// resolvers that don't chase CNAMEs for us leave the target to query
+		if len(cnames) == followed || len(cnames) == maxCNAMEs {
+			return nil, trace, zdns.STATUS_NO_RECORD, nil
+		}
+	}
+}
+
+// cnameTarget returns the target of the CNAME of name among the answers of
+// res, if any
+func cnameTarget(res interface{}, name string) string {
+	r, _ := res.(miekg.Result)
+	for _, a := range r.Answers {
+		if ans, ok := a.(miekg.Answer); ok && ans.Type == "CNAME" && strings.EqualFold(strings.TrimSuffix(ans.Name, "."), name) {
+			return strings.TrimSuffix(ans.Answer, ".")
+		}
+	}
+	return ""
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeTXT, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+func (rlf *RoutineLookupFactory) InitPrefixRegexp() {
+	rlf.PrefixRegexp = regexp.MustCompile(dkimPrefixRegexp)
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	Selectors []string
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	selectors, err := f.GetString("dkim-selectors")
+	if err != nil {
+		panic(err)
+	}
+	glf.Selectors = nil
+	for _, selector := range strings.Split(selectors, ",") {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			glf.Selectors = append(glf.Selectors, selector)
+		}
+	}
+	if len(glf.Selectors) == 0 {
+		glf.Selectors = DefaultSelectors
+	}
+}
+
+// ParseLine accepts lines of the form domain[,selector[,nameServer]], looking
+// up only the given selector
+func (glf *GlobalLookupFactory) ParseLine(line string) (string, string) {
+	s := strings.SplitN(line, ",", 3)
+	name := s[0]
+	if len(s) > 1 && strings.TrimSpace(s[1]) != "" {
+		name = strings.TrimSpace(s[1]) + domainKeyLabel + name
+	}
+	if len(s) > 2 {
+		return name, util.AddDefaultPortToDNSServerName(s[2])
+	}
+	return name, ""
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.InitPrefixRegexp()
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	s.Selectors = DefaultSelectors
+	zdns.RegisterLookup("DKIM", s)
+}
@@ -0,0 +1,187 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dkim
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+var mockResults = make(map[string]miekg.Result)
+var queries []QueryRecord
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if res, ok := mockResults[question.Name]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{}, nil, zdns.STATUS_NXDOMAIN, nil
+	}
+}
+
+func InitTest(selectors ...string) (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[string]miekg.Result)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+	glf.Selectors = selectors
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+	rlf.InitPrefixRegexp()
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func rsaKey(t *testing.T, bits int) string {
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	assert.NilError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func txt(name, record string) miekg.Result {
+	return miekg.Result{Answers: []interface{}{miekg.Answer{Name: name, Type: "TXT", Answer: record}}}
+}
+
+func TestDkimLookup_Selectors(t *testing.T) {
+	_, _, _, l := InitTest("default", "s1", "s2")
+	p := rsaKey(t, 1024)
+	mockResults["s1._domainkey.zdns-testing.com"] = txt("s1._domainkey.zdns-testing.com", "v=DKIM1; k=rsa; p="+p)
+	mockResults["s2._domainkey.zdns-testing.com"] = txt("s2._domainkey.zdns-testing.com", "v=DKIM1; p=")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, len(queries), 3)
+	for i, name := range []string{"default", "s1", "s2"} {
+		assert.Equal(t, queries[i].Name, name+"._domainkey.zdns-testing.com")
+		assert.Equal(t, queries[i].Type, dns.TypeTXT)
+	}
+	keys := res.(Result).Keys
+	assert.Equal(t, len(keys), 2)
+	assert.Equal(t, keys[0].Selector, "s1")
+	assert.Equal(t, keys[0].Domain, "zdns-testing.com")
+	assert.Equal(t, keys[0].KeyType, "rsa")
+	assert.Equal(t, keys[0].KeyBits, 1024)
+	assert.Equal(t, keys[0].Tags.P, p)
+	assert.Equal(t, len(keys[0].Errors), 0)
+	assert.Equal(t, keys[1].Selector, "s2")
+	assert.Equal(t, keys[1].Revoked, true)
+}
+
+func TestDkimLookup_Selector(t *testing.T) {
+	_, _, _, l := InitTest("default")
+	mockResults["sel._domainkey.zdns-testing.com"] = txt("sel._domainkey.zdns-testing.com", "p="+rsaKey(t, 2048))
+	res, _, status, _ := l.DoLookup("sel._domainkey.zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, len(queries), 1)
+	keys := res.(Result).Keys
+	assert.Equal(t, keys[0].Selector, "sel")
+	assert.Equal(t, keys[0].KeyBits, 2048)
+}
+
+func TestDkimLookup_CNAME(t *testing.T) {
+	_, _, _, l := InitTest("s1")
+	mockResults["s1._domainkey.zdns-testing.com"] = miekg.Result{Answers: []interface{}{
+		miekg.Answer{Name: "s1._domainkey.zdns-testing.com.", Type: "CNAME", Answer: "s1.domainkey.provider.example."},
+	}}
+	mockResults["s1.domainkey.provider.example"] = txt("s1.domainkey.provider.example", "v=DKIM1; p="+rsaKey(t, 1024))
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, queries[1].Name, "s1.domainkey.provider.example")
+	keys := res.(Result).Keys
+	assert.DeepEqual(t, keys[0].CNAMEs, []string{"s1.domainkey.provider.example"})
+	assert.Equal(t, keys[0].KeyBits, 1024)
+}
+
+func TestDkimLookup_ChasedCNAME(t *testing.T) {
+	_, _, _, l := InitTest("s1")
+	p := rsaKey(t, 1024)
+	mockResults["s1._domainkey.zdns-testing.com"] = miekg.Result{Answers: []interface{}{
+		miekg.Answer{Name: "s1._domainkey.zdns-testing.com.", Type: "CNAME", Answer: "s1.domainkey.provider.example."},
+		miekg.Answer{Name: "s1.domainkey.provider.example.", Type: "CNAME", Answer: "s1.keys.provider.example."},
+		miekg.Answer{Name: "s1.keys.provider.example.", Type: "TXT", Answer: "v=DKIM1; p=" + p},
+	}}
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, len(queries), 1)
+	keys := res.(Result).Keys
+	assert.DeepEqual(t, keys[0].CNAMEs, []string{"s1.domainkey.provider.example", "s1.keys.provider.example"})
+	assert.Equal(t, keys[0].KeyBits, 1024)
+}
+
+func TestDkimLookup_NoKeys(t *testing.T) {
+	_, _, _, l := InitTest("s1", "s2")
+	mockResults["s1._domainkey.zdns-testing.com"] = txt("s1._domainkey.zdns-testing.com", "some TXT record")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NO_RECORD, status)
+	assert.Equal(t, res, nil)
+}
+
+func TestParseKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	key := ParseKey("v=DKIM1; k=ed25519; h=sha256; t=y:s; p=" + base64.StdEncoding.EncodeToString(pub))
+	assert.Equal(t, len(key.Errors), 0)
+	assert.Equal(t, key.KeyType, "ed25519")
+	assert.Equal(t, key.KeyBits, 256)
+	assert.Equal(t, key.Testing, true)
+	assert.DeepEqual(t, key.Tags.T, []string{"y", "s"})
+	assert.DeepEqual(t, key.Tags.H, []string{"sha256"})
+
+	key = ParseKey("k=rsa; p=bm90IGEga2V5")
+	assert.Equal(t, key.KeyBits, 0)
+	assert.Equal(t, len(key.Errors), 1)
+
+	key = ParseKey("k=dsa; p=bm90IGEga2V5; v=DKIM1")
+	assert.DeepEqual(t, key.Errors, []string{`invalid v "DKIM1"`, `unknown key type "dsa"`})
+
+	key = ParseKey("v=DKIM1; k=rsa")
+	assert.DeepEqual(t, key.Errors, []string{"missing p tag"})
+}
+
+func TestParseLine(t *testing.T) {
+	glf := new(GlobalLookupFactory)
+	name, nameServer := glf.ParseLine("zdns-testing.com")
+	assert.Equal(t, name, "zdns-testing.com")
+	assert.Equal(t, nameServer, "")
+	name, nameServer = glf.ParseLine("zdns-testing.com,s1")
+	assert.Equal(t, name, "s1._domainkey.zdns-testing.com")
+	assert.Equal(t, nameServer, "")
+	name, nameServer = glf.ParseLine("zdns-testing.com,s1,8.8.8.8")
+	assert.Equal(t, name, "s1._domainkey.zdns-testing.com")
+	assert.Equal(t, nameServer, "8.8.8.8:53")
+}
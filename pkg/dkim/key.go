@@ -0,0 +1,148 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dkim
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Tags are the tags of a DKIM key record (RFC 6376, section 3.6.1)
+type Tags struct {
+	V string   `json:"v,omitempty" groups:"short,normal,long,trace"`
+	K string   `json:"k" groups:"short,normal,long,trace"`
+	P string   `json:"p" groups:"short,normal,long,trace"`
+	T []string `json:"t,omitempty" groups:"short,normal,long,trace"`
+	H []string `json:"h,omitempty" groups:"short,normal,long,trace"`
+	S []string `json:"s,omitempty" groups:"short,normal,long,trace"`
+	N string   `json:"n,omitempty" groups:"short,normal,long,trace"`
+}
+
+// ParseKey parses a DKIM key record, and the public key in it
+func ParseKey(record string) *Key {
+	key := &Key{Tags: &Tags{K: "rsa"}}
+	errorf := func(format string, args ...interface{}) {
+		key.Errors = append(key.Errors, fmt.Sprintf(format, args...))
+	}
+	tags := key.Tags
+	seen := make(map[string]bool)
+	hasP := false
+	for i, part := range strings.Split(record, ";") {
+		part = strings.Trim(part, " \t\r\n")
+		if part == "" {
+			continue
+		}
+		eq := strings.IndexByte(part, '=')
+		if eq < 0 {
+			errorf("invalid tag %q", part)
+			continue
+		}
+		name, value := strings.Trim(part[:eq], " \t\r\n"), strings.Trim(part[eq+1:], " \t\r\n")
+		if seen[name] {
+			errorf("more than one %s tag", name)
+			continue
+		}
+		seen[name] = true
+		switch name {
+		case "v":
+			if i != 0 || value != "DKIM1" {
+				errorf("invalid v %q", value)
+			}
+			tags.V = value
+		case "k":
+			tags.K = value
+		case "p":
+			hasP = true
+			// whitespace is allowed within the base64 data
+			tags.P = strings.Join(strings.Fields(value), "")
+		case "t":
+			tags.T = splitList(value)
+		case "h":
+			tags.H = splitList(value)
+		case "s":
+			tags.S = splitList(value)
+		case "n":
+			tags.N = value
+		}
+		// unknown tags are ignored
+	}
+	for _, flag := range tags.T {
+		key.Testing = key.Testing || flag == "y"
+	}
+	key.KeyType = strings.ToLower(tags.K)
+	if !hasP {
+		errorf("missing p tag")
+		return key
+	}
+	if tags.P == "" {
+		key.Revoked = true
+		return key
+	}
+	der, err := base64.StdEncoding.DecodeString(tags.P)
+	if err != nil {
+		errorf("invalid public key: %v", err)
+		return key
+	}
+	switch key.KeyType {
+	case "rsa":
+		pub, err := parseRSAKey(der)
+		if err != nil {
+			errorf("invalid RSA key: %v", err)
+			return key
+		}
+		key.KeyBits = pub.N.BitLen()
+	case "ed25519":
+		// the raw key (RFC 8463)
+		if len(der) != ed25519.PublicKeySize {
+			errorf("invalid Ed25519 key: %d bytes", len(der))
+			return key
+		}
+		key.KeyBits = 8 * len(der)
+	default:
+		errorf("unknown key type %q", tags.K)
+	}
+	return key
+}
+
+// parseRSAKey parses an RSA key, which is a SubjectPublicKeyInfo, although
+// some signers publish the bare RSAPublicKey
+func parseRSAKey(der []byte) (*rsa.PublicKey, error) {
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		if rsaPub, pkcs1Err := x509.ParsePKCS1PublicKey(der); pkcs1Err == nil {
+			return rsaPub, nil
+		}
+		return nil, err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an RSA key", pub)
+	}
+	return rsaPub, nil
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ":") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -15,19 +15,45 @@
 package dmarc
 
 import (
+	"errors"
+	"regexp"
+	"strings"
+
 	"github.com/zmap/dns"
 	"github.com/zmap/zdns/pkg/miekg"
 	"github.com/zmap/zdns/pkg/zdns"
-	"regexp"
+	"golang.org/x/net/publicsuffix"
 )
 
 const dmarcPrefixRegexp = "^[vV][\x09\x20]*=[\x09\x20]*DMARC1[\x09\x20]*;[\x09\x20]*"
 
+var prefixRegexp = regexp.MustCompile(dmarcPrefixRegexp)
+
 // result to be returned by scan of host
 type Result struct {
 	Dmarc string `json:"dmarc,omitempty" groups:"short,normal,long,trace"`
+	// the domain the record was found for, which is the organizational
+	// domain of the name if the name has no record of its own
+	Domain   string `json:"domain,omitempty" groups:"short,normal,long,trace"`
+	Fallback bool   `json:"organizational_domain_fallback,omitempty" groups:"short,normal,long,trace"`
+	Tags     *Tags  `json:"tags,omitempty" groups:"short,normal,long,trace"`
+	// report destinations outside of the organizational domain, which have to
+	// authorize the domain to send reports to them
+	ExternalReports []ExternalReport `json:"external_reports,omitempty" groups:"short,normal,long,trace"`
+	Errors          []string         `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// ExternalReport is the verification of an external report destination (RFC
+// 7489, section 7.1)
+type ExternalReport struct {
+	URI        string `json:"uri" groups:"short,normal,long,trace"`
+	Domain     string `json:"domain" groups:"short,normal,long,trace"`
+	Authorized bool   `json:"authorized" groups:"short,normal,long,trace"`
+	Error      string `json:"error,omitempty" groups:"short,normal,long,trace"`
 }
 
+const dmarcPrefix = "_dmarc."
+
 // Per Connection Lookup ======================================================
 //
 type Lookup struct {
@@ -35,11 +61,123 @@ type Lookup struct {
 	miekg.Lookup
 }
 
+// DoLookup looks up the DMARC record of name, which may be given with or
+// without the _dmarc label, falling back to its organizational domain
 func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
-	innerRes, trace, status, err := s.DoMiekgLookup(miekg.Question{Name: name, Type: s.DNSType, Class: s.DNSClass}, nameServer)
-	resString, resStatus, err := s.CheckTxtRecords(innerRes, status, err)
-	res := Result{Dmarc: resString}
-	return res, trace, resStatus, err
+	var trace zdns.Trace
+	query := func(qname string) ([]interface{}, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: dns.TypeTXT, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		return answers(res), status, err
+	}
+	res, status, err := Find(query, name)
+	return res, trace, status, err
+}
+
+// QueryFunc returns the TXT answers of name, for modules that look up DMARC
+// records along with their own lookups
+type QueryFunc func(name string) ([]interface{}, zdns.Status, error)
+
+var errNoRecord = errors.New("no such TXT record found")
+
+// Find looks up the DMARC record of name with query, falling back to its
+// organizational domain, parses it and verifies its external report
+// destinations
+func Find(query QueryFunc, name string) (Result, zdns.Status, error) {
+	domain := strings.TrimSuffix(name, ".")
+	if strings.HasPrefix(strings.ToLower(domain), dmarcPrefix) {
+		domain = domain[len(dmarcPrefix):]
+	}
+	res := Result{Domain: domain}
+	records, status, err := findRecords(query, dmarcPrefix+domain)
+	if len(records) == 0 && (status == zdns.STATUS_NOERROR || status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER) {
+		if org := OrganizationalDomain(domain); org != "" && !strings.EqualFold(org, domain) {
+			records, status, err = findRecords(query, dmarcPrefix+org)
+			res.Domain, res.Fallback = org, true
+		}
+	}
+	if status != zdns.STATUS_NOERROR {
+		return Result{}, status, err
+	}
+	if len(records) == 0 {
+		return Result{}, zdns.STATUS_NO_RECORD, errNoRecord
+	}
+	res.Dmarc = records[0]
+	if len(records) > 1 {
+		// receivers don't apply any policy then (RFC 7489, section 6.6.3)
+		res.Errors = append(res.Errors, "more than one DMARC record")
+	}
+	// the strings of a TXT record are joined with newlines by ParseAnswer
+	tags, errs := ParseRecord(prefixRegexp.ReplaceAllString(strings.ReplaceAll(res.Dmarc, "\n", ""), "v=DMARC1;"))
+	res.Tags = tags
+	res.Errors = append(res.Errors, errs...)
+	verifyExternalReports(query, &res)
+	return res, zdns.STATUS_NOERROR, nil
+}
+
+// findRecords returns the DMARC records among the TXT records of name
+func findRecords(query QueryFunc, name string) ([]string, zdns.Status, error) {
+	answers, status, err := query(name)
+	if status != zdns.STATUS_NOERROR {
+		return nil, status, err
+	}
+	var records []string
+	for _, a := range answers {
+		if ans, ok := a.(miekg.Answer); ok && prefixRegexp.MatchString(ans.Answer) {
+			records = append(records, ans.Answer)
+		}
+	}
+	return records, status, err
+}
+
+// verifyExternalReports checks that the destinations of rua and ruf that are
+// outside of the organizational domain accept reports for the domain
+func verifyExternalReports(query QueryFunc, res *Result) {
+	org := OrganizationalDomain(res.Domain)
+	verified := make(map[string]ExternalReport)
+	seen := make(map[string]bool)
+	for _, uri := range append(append([]string{}, res.Tags.Rua...), res.Tags.Ruf...) {
+		dest := reportDomain(uri)
+		if dest == "" || strings.EqualFold(OrganizationalDomain(dest), org) || seen[uri] {
+			continue
+		}
+		seen[uri] = true
+		ext, ok := verified[dest]
+		if !ok {
+			ext = ExternalReport{Domain: dest}
+			records, status, err := findRecords(query, res.Domain+"._report._dmarc."+dest)
+			switch {
+			case len(records) > 0:
+				ext.Authorized = true
+			case status == zdns.STATUS_NOERROR || status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER:
+			case err != nil:
+				ext.Error = string(status) + ": " + err.Error()
+			default:
+				ext.Error = string(status)
+			}
+			verified[dest] = ext
+		}
+		ext.URI = uri
+		res.ExternalReports = append(res.ExternalReports, ext)
+		if !ext.Authorized && ext.Error == "" {
+			res.Errors = append(res.Errors, "report destination "+dest+" does not accept reports for "+res.Domain)
+		}
+	}
+}
+
+// OrganizationalDomain returns the organizational domain of name (RFC 7489,
+// section 3.2), using the embedded Public Suffix List
+func OrganizationalDomain(name string) string {
+	org, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(strings.TrimSuffix(name, ".")))
+	if err != nil {
+		return ""
+	}
+	return org
+}
+
+func answers(res interface{}) []interface{} {
+	r, _ := res.(miekg.Result)
+	return r.Answers
 }
 
 // Per GoRoutine Factory ======================================================
@@ -57,7 +195,7 @@ func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
 }
 
 func (rlf *RoutineLookupFactory) InitPrefixRegexp() {
-	rlf.PrefixRegexp = regexp.MustCompile(dmarcPrefixRegexp)
+	rlf.PrefixRegexp = prefixRegexp
 }
 
 // Global Factory =============================================================
@@ -151,3 +151,83 @@ func TestDmarcLookup_NotValid_3(t *testing.T) {
 	assert.Equal(t, zdns.STATUS_NO_RECORD, status)
 	assert.Equal(t, res.(Result).Dmarc, "")
 }
+
+func TestDmarcLookup_OrganizationalDomain(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["_dmarc.example.co.uk"] = miekg.Result{
+		Answers: []interface{}{
+			miekg.Answer{Name: "_dmarc.example.co.uk", Answer: "v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.co.uk,mailto:reports@vendor.example!10m; adkim=s"}},
+	}
+	mockResults["example.co.uk._report._dmarc.vendor.example"] = miekg.Result{
+		Answers: []interface{}{
+			miekg.Answer{Name: "example.co.uk._report._dmarc.vendor.example", Answer: "v=DMARC1;"}},
+	}
+	res, _, status, _ := l.DoLookup("mail.example.co.uk", "")
+	assert.Equal(t, queries[0].Name, "_dmarc.mail.example.co.uk")
+	assert.Equal(t, queries[1].Name, "_dmarc.example.co.uk")
+	assert.Equal(t, queries[2].Name, "example.co.uk._report._dmarc.vendor.example")
+	assert.Equal(t, len(queries), 3)
+
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, r.Domain, "example.co.uk")
+	assert.Assert(t, r.Fallback)
+	assert.Equal(t, r.Tags.P, "reject")
+	assert.Equal(t, r.Tags.SP, "quarantine")
+	assert.Equal(t, r.Tags.Pct, 50)
+	assert.Equal(t, r.Tags.Adkim, "s")
+	assert.Equal(t, r.Tags.Aspf, "r")
+	assert.DeepEqual(t, r.Tags.Rua, []string{"mailto:dmarc@example.co.uk", "mailto:reports@vendor.example!10m"})
+	assert.DeepEqual(t, r.ExternalReports, []ExternalReport{{URI: "mailto:reports@vendor.example!10m", Domain: "vendor.example", Authorized: true}})
+	assert.Equal(t, len(r.Errors), 0)
+}
+
+func TestDmarcLookup_UnauthorizedReports(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["_dmarc.zdns-testing.com"] = miekg.Result{
+		Answers: []interface{}{
+			miekg.Answer{Name: "_dmarc.zdns-testing.com", Answer: "v=DMARC1; p=none; ruf=mailto:postmaster@censys.io"}},
+	}
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Assert(t, !r.Fallback)
+	assert.Equal(t, queries[1].Name, "zdns-testing.com._report._dmarc.censys.io")
+	assert.DeepEqual(t, r.ExternalReports, []ExternalReport{{URI: "mailto:postmaster@censys.io", Domain: "censys.io"}})
+	assert.DeepEqual(t, r.Errors, []string{"report destination censys.io does not accept reports for zdns-testing.com"})
+}
+
+func TestDmarcLookup_NoFallback(t *testing.T) {
+	_, _, _, l := InitTest()
+	res, _, status, _ := l.DoLookup("_dmarc.zdns-testing.com", "")
+	assert.Equal(t, len(queries), 1)
+	assert.Equal(t, zdns.STATUS_NO_ANSWER, status)
+	assert.Equal(t, res.(Result).Dmarc, "")
+}
+
+func TestParseRecord(t *testing.T) {
+	tags, errs := ParseRecord("v=DMARC1; p=quarantine; fo=1:d; ri=3600; rf=afrf")
+	assert.Equal(t, len(errs), 0)
+	assert.Equal(t, tags.SP, "quarantine")
+	assert.Equal(t, tags.Fo, "1:d")
+	assert.Equal(t, tags.Ri, uint32(3600))
+	assert.Equal(t, tags.Pct, 100)
+
+	tags, errs = ParseRecord("v=DMARC1; p=block; pct=150; adkim=x; fo=2; rua=https://example.com/reports; p=none; foo")
+	assert.DeepEqual(t, errs, []string{
+		`invalid p "block"`,
+		`invalid pct "150"`,
+		`invalid adkim "x"`,
+		`invalid fo "2"`,
+		`invalid rua URI "https://example.com/reports": unsupported scheme "https"`,
+		"more than one p tag",
+		`invalid tag "foo"`,
+		"missing p tag",
+	})
+	assert.Equal(t, tags.Pct, 100)
+
+	// p defaults to none if there are report destinations
+	tags, errs = ParseRecord("v=DMARC1; rua=mailto:dmarc@example.com")
+	assert.DeepEqual(t, errs, []string{"missing p tag"})
+	assert.Equal(t, tags.P, "none")
+}
@@ -0,0 +1,195 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package dmarc
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tags are the tags of a DMARC record (RFC 7489, section 6.3), with the
+// defaults of the tags that aren't given
+type Tags struct {
+	P     string   `json:"p,omitempty" groups:"short,normal,long,trace"`
+	SP    string   `json:"sp,omitempty" groups:"short,normal,long,trace"`
+	Pct   int      `json:"pct" groups:"short,normal,long,trace"`
+	Rua   []string `json:"rua,omitempty" groups:"short,normal,long,trace"`
+	Ruf   []string `json:"ruf,omitempty" groups:"short,normal,long,trace"`
+	Adkim string   `json:"adkim" groups:"short,normal,long,trace"`
+	Aspf  string   `json:"aspf" groups:"short,normal,long,trace"`
+	Fo    string   `json:"fo" groups:"short,normal,long,trace"`
+	Rf    string   `json:"rf" groups:"short,normal,long,trace"`
+	Ri    uint32   `json:"ri" groups:"short,normal,long,trace"`
+}
+
+// sizeLimitRegexp matches the size limit that may follow a report URI, e.g.,
+// !10m
+var sizeLimitRegexp = regexp.MustCompile(`![0-9]+[kmgt]?$`)
+
+// ParseRecord parses the tags of a DMARC record. Problems that would make
+// receivers ignore the record or some of its tags are returned as errors,
+// along with the tags that could be parsed.
+func ParseRecord(record string) (*Tags, []string) {
+	tags := &Tags{Pct: 100, Adkim: "r", Aspf: "r", Fo: "0", Rf: "afrf", Ri: 86400}
+	var errs []string
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+	seen := make(map[string]bool)
+	for i, part := range strings.Split(record, ";") {
+		part = strings.Trim(part, " \t")
+		if part == "" {
+			continue
+		}
+		eq := strings.IndexByte(part, '=')
+		if eq < 0 {
+			errorf("invalid tag %q", part)
+			continue
+		}
+		name, value := strings.ToLower(strings.Trim(part[:eq], " \t")), strings.Trim(part[eq+1:], " \t")
+		if i == 0 {
+			// the version has been checked by the prefix regexp
+			continue
+		}
+		if seen[name] {
+			errorf("more than one %s tag", name)
+			continue
+		}
+		seen[name] = true
+		switch name {
+		case "v":
+			errorf("v tag is not first")
+		case "p", "sp":
+			v := strings.ToLower(value)
+			if v != "none" && v != "quarantine" && v != "reject" {
+				errorf("invalid %s %q", name, value)
+				continue
+			}
+			if name == "p" {
+				tags.P = v
+			} else {
+				tags.SP = v
+			}
+		case "pct":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 100 {
+				errorf("invalid pct %q", value)
+				continue
+			}
+			tags.Pct = n
+		case "rua", "ruf":
+			var uris []string
+			for _, uri := range strings.Split(value, ",") {
+				uri = strings.Trim(uri, " \t")
+				if err := checkReportURI(uri); err != nil {
+					errorf("invalid %s URI %q: %v", name, uri, err)
+					continue
+				}
+				uris = append(uris, uri)
+			}
+			if name == "rua" {
+				tags.Rua = uris
+			} else {
+				tags.Ruf = uris
+			}
+		case "adkim", "aspf":
+			v := strings.ToLower(value)
+			if v != "r" && v != "s" {
+				errorf("invalid %s %q", name, value)
+				continue
+			}
+			if name == "adkim" {
+				tags.Adkim = v
+			} else {
+				tags.Aspf = v
+			}
+		case "fo":
+			valid := true
+			for _, o := range strings.Split(value, ":") {
+				switch strings.Trim(o, " \t") {
+				case "0", "1", "d", "s":
+				default:
+					valid = false
+				}
+			}
+			if !valid {
+				errorf("invalid fo %q", value)
+				continue
+			}
+			tags.Fo = value
+		case "rf":
+			tags.Rf = strings.ToLower(value)
+		case "ri":
+			n, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				errorf("invalid ri %q", value)
+				continue
+			}
+			tags.Ri = uint32(n)
+		}
+		// unknown tags are ignored
+	}
+	if tags.P == "" {
+		if len(tags.Rua) > 0 {
+			// RFC 7489, section 6.6.3
+			tags.P = "none"
+		}
+		errorf("missing p tag")
+	}
+	if tags.SP == "" {
+		tags.SP = tags.P
+	}
+	return tags, errs
+}
+
+// checkReportURI checks a DMARC URI, which is a URI with an optional size
+// limit. Only mailto URIs are used by receivers.
+func checkReportURI(uri string) error {
+	_, err := reportAddress(uri)
+	return err
+}
+
+// reportAddress returns the mailbox of a DMARC URI
+func reportAddress(uri string) (string, error) {
+	u, err := url.Parse(sizeLimitRegexp.ReplaceAllString(uri, ""))
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(u.Scheme, "mailto") {
+		return "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	opaque, err := url.PathUnescape(u.Opaque)
+	if err != nil {
+		return "", err
+	}
+	addr, err := mail.ParseAddress(opaque)
+	if err != nil {
+		return "", err
+	}
+	return addr.Address, nil
+}
+
+// reportDomain returns the domain of the mailbox of a DMARC URI
+func reportDomain(uri string) string {
+	addr, err := reportAddress(uri)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(addr[strings.LastIndexByte(addr, '@')+1:])
+}
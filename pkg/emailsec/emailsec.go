@@ -0,0 +1,216 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package emailsec
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/dane"
+	"github.com/zmap/zdns/pkg/dmarc"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/mtasts"
+	"github.com/zmap/zdns/pkg/mxlookup"
+	"github.com/zmap/zdns/pkg/spf"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// result to be returned by scan of host
+type Result struct {
+	Summary  Summary   `json:"summary" groups:"short,normal,long,trace"`
+	Findings []Finding `json:"findings,omitempty" groups:"short,normal,long,trace"`
+	// the MX hosts of the domain by preference, or the domain itself if it
+	// has no MX records (implicit MX)
+	MX []MXHost `json:"mx,omitempty" groups:"short,normal,long,trace"`
+	// the domain publishes a null MX (RFC 7505), and doesn't accept mail
+	NullMX bool                 `json:"null_mx,omitempty" groups:"short,normal,long,trace"`
+	SPF    *spf.Evaluation      `json:"spf,omitempty" groups:"short,normal,long,trace"`
+	DMARC  *dmarc.Result        `json:"dmarc,omitempty" groups:"short,normal,long,trace"`
+	MTASTS *mtasts.STSRecord    `json:"mta_sts,omitempty" groups:"short,normal,long,trace"`
+	TLSRPT *mtasts.TLSRPTRecord `json:"tls_rpt,omitempty" groups:"short,normal,long,trace"`
+	// the MTA-STS policy, with --mta-sts-fetch-policy
+	MTASTSPolicy *mtasts.Policy `json:"mta_sts_policy,omitempty" groups:"short,normal,long,trace"`
+}
+
+// MXHost is an MX host of the domain, with its TLSA records for SMTP (RFC
+// 7672)
+type MXHost struct {
+	Name       string            `json:"name" groups:"short,normal,long,trace"`
+	Preference uint16            `json:"preference" groups:"short,normal,long,trace"`
+	Implicit   bool              `json:"implicit,omitempty" groups:"short,normal,long,trace"`
+	TLSA       []dane.TLSARecord `json:"tlsa,omitempty" groups:"short,normal,long,trace"`
+	TLSASecure bool              `json:"tlsa_secure" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+// DoLookup checks the email security of name: its MX hosts, SPF, DMARC,
+// MTA-STS and TLS-RPT records, and the TLSA records of its MX hosts
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	// for whether MX and TLSA records are secure, see dane.QueryFunc
+	s.Options.DNSSEC = true
+	var trace zdns.Trace
+	// the checks look up some names more than once, e.g., the MX records
+	cache := make(map[miekg.Question]struct {
+		res    miekg.Result
+		status zdns.Status
+		err    error
+	})
+	lookup := func(qname string, qtype uint16) (miekg.Result, zdns.Status, error) {
+		key := miekg.Question{Name: strings.ToLower(strings.TrimSuffix(qname, ".")), Type: qtype, Class: dns.ClassINET}
+		c, ok := cache[key]
+		if !ok {
+			res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: qtype, Class: dns.ClassINET}, nameServer)
+			trace = append(trace, t...)
+			c.res, c.status, c.err = result(res), status, err
+			cache[key] = c
+		}
+		return c.res, c.status, c.err
+	}
+	query := func(qname string, qtype uint16) ([]interface{}, zdns.Status, error) {
+		r, status, err := lookup(qname, qtype)
+		return r.Answers, status, err
+	}
+	secureQuery := func(qname string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		r, status, err := lookup(qname, qtype)
+		return r.Answers, r.Flags.Authenticated, status, err
+	}
+	domain := strings.ToLower(strings.TrimSuffix(name, "."))
+	var res Result
+	exchanges, status, err := mxlookup.LookupExchanges(secureQuery, domain)
+	if status != zdns.STATUS_NOERROR {
+		return nil, trace, status, err
+	}
+	res.MX, res.NullMX = mxHosts(exchanges, domain), exchanges.NullMX
+	// the TLSA lookups that failed, by MX host
+	tlsaErrors := make(map[string]string)
+	for i := range res.MX {
+		mx := &res.MX[i]
+		var tlsaStatus zdns.Status
+		var tlsaErr error
+		mx.TLSA, mx.TLSASecure, tlsaStatus, tlsaErr = dane.LookupTLSA(secureQuery, "_25._tcp."+mx.Name, true)
+		if miekg.LookupFailed(tlsaStatus) {
+			tlsaErrors[mx.Name] = miekg.LookupError(tlsaStatus, tlsaErr)
+		}
+	}
+	res.SPF = spf.EvaluateDomain(spf.QueryFunc(query), domain, nil, "")
+	dmarcRes, dmarcStatus, dmarcErr := dmarc.Find(func(qname string) ([]interface{}, zdns.Status, error) {
+		return query(qname, dns.TypeTXT)
+	}, domain)
+	if dmarcStatus == zdns.STATUS_NOERROR {
+		res.DMARC = &dmarcRes
+	}
+	var client *http.Client
+	if s.Factory.Factory.FetchPolicy {
+		client = s.Factory.Factory.HTTPClient
+	}
+	stsRes, stsStatus, stsErr := mtasts.Find(mtasts.QueryFunc(query), domain, client)
+	res.MTASTS, res.TLSRPT, res.MTASTSPolicy = stsRes.MTASTS, stsRes.TLSRPT, stsRes.Policy
+	var p posture
+	p.checkMX(&res)
+	p.checkSPF(&res)
+	p.checkDMARC(&res, dmarcStatus, dmarcErr)
+	p.checkMTASTS(&res, stsRes.Errors, stsStatus, stsErr)
+	p.checkDANE(&res, tlsaErrors)
+	res.Findings = p.findings
+	res.Summary = summarize(&res, p.findings)
+	return res, trace, zdns.STATUS_NOERROR, nil
+}
+
+// mxHosts returns the MX hosts of domain by preference, or the domain itself
+// if it has no MX records
+func mxHosts(exchanges mxlookup.Exchanges, domain string) []MXHost {
+	if exchanges.Implicit() {
+		return []MXHost{{Name: domain, Implicit: true}}
+	}
+	var hosts []MXHost
+	for _, ans := range exchanges.Records {
+		hosts = append(hosts, MXHost{Name: strings.ToLower(strings.TrimSuffix(ans.Answer.Answer, ".")), Preference: ans.Preference})
+	}
+	return hosts
+}
+
+func result(res interface{}) miekg.Result {
+	r, _ := res.(miekg.Result)
+	return r
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeMX, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	FetchPolicy bool
+	// the client MTA-STS policies are fetched with
+	HTTPClient *http.Client
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	glf.FetchPolicy, err = f.GetBool("mta-sts-fetch-policy")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (glf *GlobalLookupFactory) Initialize(c *zdns.GlobalConf) error {
+	if err := glf.GlobalLookupFactory.Initialize(c); err != nil {
+		return err
+	}
+	if glf.HTTPClient == nil {
+		glf.HTTPClient = mtasts.NewPolicyClient(c.Timeout)
+	}
+	return nil
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	zdns.RegisterLookup("EMAILSEC", s)
+}
@@ -0,0 +1,197 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package emailsec
+
+import (
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+type mockKey struct {
+	name  string
+	qtype uint16
+}
+
+// the checks look up different types at the same names
+var mockResults = make(map[mockKey]miekg.Result)
+var mockFailures = make(map[mockKey]zdns.Status)
+var queries []QueryRecord
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if status, ok := mockFailures[mockKey{question.Name, question.Type}]; ok {
+		return miekg.Result{}, nil, status, nil
+	}
+	if res, ok := mockResults[mockKey{question.Name, question.Type}]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{}, nil, zdns.STATUS_NO_ANSWER, nil
+	}
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[mockKey]miekg.Result)
+	mockFailures = make(map[mockKey]zdns.Status)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func mockTXT(name string, records ...string) {
+	var res miekg.Result
+	for _, record := range records {
+		res.Answers = append(res.Answers, miekg.Answer{Name: name, Type: "TXT", Answer: record})
+	}
+	mockResults[mockKey{name, dns.TypeTXT}] = res
+}
+
+func mockMX(name string, hosts map[string]uint16) {
+	var res miekg.Result
+	for host, pref := range hosts {
+		res.Answers = append(res.Answers, miekg.PrefAnswer{Answer: miekg.Answer{Name: name, Type: "MX", Answer: host}, Preference: pref})
+	}
+	mockResults[mockKey{name, dns.TypeMX}] = res
+}
+
+func mockTLSA(host string, secure bool) {
+	name := "_25._tcp." + host
+	mockResults[mockKey{name, dns.TypeTLSA}] = miekg.Result{
+		Answers: []interface{}{
+			miekg.TLSAAnswer{Answer: miekg.Answer{Name: name, Type: "TLSA"}, CertUsage: 3, Selector: 1, MatchingType: 1, Certificate: "0c72ac70b745ac19998811b131d662c9ac69dbdbe7cb23e5b514b56664c5d3d6"},
+		},
+		Flags: miekg.DNSFlags{Authenticated: secure},
+	}
+}
+
+func TestEmailSecLookup_Strong(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockMX("zdns-testing.com", map[string]uint16{"mx2.zdns-testing.com.": 20, "mx1.zdns-testing.com.": 10})
+	mockTLSA("mx1.zdns-testing.com", true)
+	mockTLSA("mx2.zdns-testing.com", true)
+	mockTXT("zdns-testing.com", "v=spf1 ip4:192.0.2.0/24 -all")
+	mockTXT("_dmarc.zdns-testing.com", "v=DMARC1; p=reject")
+	mockTXT("_mta-sts.zdns-testing.com", "v=STSv1; id=1")
+	mockTXT("_smtp._tls.zdns-testing.com", "v=TLSRPTv1; rua=mailto:tlsrpt@zdns-testing.com")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, len(r.Findings), 0)
+	assert.DeepEqual(t, r.Summary, Summary{Score: 100, AcceptsMail: true, SPFAll: "-all", DMARCPolicy: "reject", MTASTS: true, TLSRPT: true, DANE: true})
+	assert.Equal(t, r.MX[0].Name, "mx1.zdns-testing.com")
+	assert.Equal(t, r.MX[1].Name, "mx2.zdns-testing.com")
+	assert.Equal(t, r.MX[0].TLSA[0].Usage, uint8(3))
+	assert.Equal(t, r.MX[0].TLSASecure, true)
+	assert.Equal(t, r.SPF.Record.Text, "v=spf1 ip4:192.0.2.0/24 -all")
+	assert.Equal(t, r.DMARC.Tags.P, "reject")
+	assert.Equal(t, r.MTASTS.ID, "1")
+	// each name and type is only looked up once
+	seen := make(map[mockKey]bool)
+	for _, q := range queries {
+		assert.Assert(t, !seen[mockKey{q.Name, q.Type}], "%s looked up more than once", q.Name)
+		seen[mockKey{q.Name, q.Type}] = true
+	}
+}
+
+func TestEmailSecLookup_Weak(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockTXT("zdns-testing.com", "v=spf1 ip4:192.0.2.0/24 ~all")
+	mockTXT("_dmarc.zdns-testing.com", "v=DMARC1; p=none")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.DeepEqual(t, r.MX, []MXHost{{Name: "zdns-testing.com", Implicit: true}})
+	assert.DeepEqual(t, r.Findings, []Finding{
+		{Check: "mx", Severity: SeverityLow, Message: "no MX records: mail is delivered to the address records of the domain"},
+		{Check: "spf", Severity: SeverityLow, Message: "the SPF policy soft-fails other senders (~all)"},
+		{Check: "dmarc", Severity: SeverityMedium, Message: "the DMARC policy is none"},
+		{Check: "mta-sts", Severity: SeverityLow, Message: "no MTA-STS record"},
+		{Check: "tls-rpt", Severity: SeverityLow, Message: "no TLS-RPT record"},
+		{Check: "dane", Severity: SeverityLow, Message: "no TLSA records for MX host zdns-testing.com"},
+	})
+	assert.DeepEqual(t, r.Summary, Summary{Score: 65, AcceptsMail: true, SPFAll: "~all", DMARCPolicy: "none"})
+}
+
+func TestEmailSecLookup_NullMX(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockMX("zdns-testing.com", map[string]uint16{".": 0})
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, r.NullMX, true)
+	assert.DeepEqual(t, r.Findings, []Finding{
+		{Check: "mx", Severity: SeverityInfo, Message: "null MX: the domain doesn't accept mail"},
+		{Check: "spf", Severity: SeverityHigh, Message: "no SPF record"},
+		{Check: "dmarc", Severity: SeverityHigh, Message: "no DMARC record"},
+	})
+	assert.Equal(t, r.Summary.Score, 50)
+	assert.Equal(t, r.Summary.AcceptsMail, false)
+}
+
+func TestEmailSecLookup_SPFRedirect(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockTXT("zdns-testing.com", "v=spf1 redirect=_spf.zdns-testing.com")
+	mockTXT("_spf.zdns-testing.com", "v=spf1 +all")
+	res, _, _, _ := l.DoLookup("zdns-testing.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.Summary.SPFAll, "+all")
+	assert.DeepEqual(t, r.Findings[1], Finding{Check: "spf", Severity: SeverityHigh, Message: "the SPF policy passes every sender (+all)"})
+}
+
+func TestEmailSecLookup_DANE(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockMX("zdns-testing.com", map[string]uint16{"mx1.zdns-testing.com.": 10, "mx2.zdns-testing.com.": 20})
+	mockTLSA("mx1.zdns-testing.com", false)
+	mockFailures[mockKey{"_25._tcp.mx2.zdns-testing.com", dns.TypeTLSA}] = zdns.STATUS_SERVFAIL
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, len(r.MX[0].TLSA), 1)
+	assert.Equal(t, r.MX[0].TLSASecure, false)
+	assert.DeepEqual(t, r.Findings[len(r.Findings)-2:], []Finding{
+		{Check: "dane", Severity: SeverityMedium, Message: "TLSA records of MX host mx1.zdns-testing.com are not DNSSEC-secure"},
+		{Check: "dane", Severity: SeverityMedium, Message: "TLSA lookup failed for MX host mx2.zdns-testing.com: SERVFAIL"},
+	})
+	assert.Equal(t, r.Summary.DANE, false)
+}
+
+func TestEmailSecLookup_DMARCFallback(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockTXT("_dmarc.zdns-testing.com", "v=DMARC1; p=reject; sp=none")
+	res, _, _, _ := l.DoLookup("mail.zdns-testing.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.DMARC.Fallback, true)
+	assert.Equal(t, r.Summary.DMARCPolicy, "none")
+}
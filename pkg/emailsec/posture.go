@@ -0,0 +1,242 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package emailsec
+
+import (
+	"fmt"
+
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/spf"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// Severities of findings, and the points they take off the score
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+	SeverityInfo   = "info"
+)
+
+var penalties = map[string]int{
+	SeverityHigh:   25,
+	SeverityMedium: 10,
+	SeverityLow:    5,
+}
+
+// Finding is a problem with, or a notable property of, the email security of
+// the domain
+type Finding struct {
+	Check    string `json:"check" groups:"short,normal,long,trace"`
+	Severity string `json:"severity" groups:"short,normal,long,trace"`
+	Message  string `json:"message" groups:"short,normal,long,trace"`
+}
+
+// Summary is the outcome of each check
+type Summary struct {
+	// 100, less the penalties of the findings, down to 0
+	Score       int  `json:"score" groups:"short,normal,long,trace"`
+	AcceptsMail bool `json:"accepts_mail" groups:"short,normal,long,trace"`
+	// the all mechanism that ends the SPF policy, e.g., -all
+	SPFAll      string `json:"spf_all,omitempty" groups:"short,normal,long,trace"`
+	DMARCPolicy string `json:"dmarc_policy,omitempty" groups:"short,normal,long,trace"`
+	MTASTS      bool   `json:"mta_sts" groups:"short,normal,long,trace"`
+	// the mode of the MTA-STS policy, with --mta-sts-fetch-policy
+	MTASTSMode string `json:"mta_sts_mode,omitempty" groups:"short,normal,long,trace"`
+	TLSRPT     bool   `json:"tls_rpt" groups:"short,normal,long,trace"`
+	// every MX host has usable TLSA records, which are DNSSEC-secure
+	DANE bool `json:"dane" groups:"short,normal,long,trace"`
+}
+
+// posture collects the findings of the checks
+type posture struct {
+	findings []Finding
+}
+
+func (p *posture) add(check, severity, format string, args ...interface{}) {
+	p.findings = append(p.findings, Finding{Check: check, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *posture) checkMX(res *Result) {
+	if res.NullMX {
+		p.add("mx", SeverityInfo, "null MX: the domain doesn't accept mail")
+	} else if res.MX[0].Implicit {
+		p.add("mx", SeverityLow, "no MX records: mail is delivered to the address records of the domain")
+	}
+}
+
+func (p *posture) checkSPF(res *Result) {
+	rec := res.SPF.Record
+	if rec.Text == "" && rec.Error == "no SPF record" {
+		p.add("spf", SeverityHigh, "no SPF record")
+		return
+	}
+	for _, e := range res.SPF.Errors {
+		p.add("spf", SeverityHigh, "%s", e)
+	}
+	if rec.Text == "" {
+		return
+	}
+	switch all := spfAll(rec); all {
+	case "+all":
+		p.add("spf", SeverityHigh, "the SPF policy passes every sender (+all)")
+	case "?all":
+		p.add("spf", SeverityMedium, "the SPF policy is neutral about other senders (?all)")
+	case "~all":
+		p.add("spf", SeverityLow, "the SPF policy soft-fails other senders (~all)")
+	case "":
+		p.add("spf", SeverityMedium, "the SPF policy doesn't end with an all mechanism")
+	}
+}
+
+// spfAll returns the all mechanism of rec, following redirects
+func spfAll(rec *spf.Record) string {
+	for _, t := range rec.Terms {
+		if t.Mechanism == "all" {
+			if t.Qualifier == "" {
+				return "+all"
+			}
+			return t.Qualifier + "all"
+		}
+	}
+	for _, t := range rec.Terms {
+		if t.Modifier == "redirect" && t.Record != nil {
+			return spfAll(t.Record)
+		}
+	}
+	return ""
+}
+
+func (p *posture) checkDMARC(res *Result, status zdns.Status, err error) {
+	if miekg.LookupFailed(status) {
+		p.add("dmarc", SeverityMedium, "DMARC lookup failed: %s", miekg.LookupError(status, err))
+		return
+	}
+	if res.DMARC == nil {
+		p.add("dmarc", SeverityHigh, "no DMARC record")
+		return
+	}
+	for _, e := range res.DMARC.Errors {
+		p.add("dmarc", SeverityMedium, "%s", e)
+	}
+	switch policy := dmarcPolicy(res); {
+	case policy == "none":
+		p.add("dmarc", SeverityMedium, "the DMARC policy is none")
+	case policy != "" && res.DMARC.Tags.Pct < 100:
+		p.add("dmarc", SeverityLow, "the DMARC policy applies to %d%% of messages", res.DMARC.Tags.Pct)
+	}
+}
+
+// dmarcPolicy returns the policy that applies to the domain: the subdomain
+// policy of the organizational domain if its record is used
+func dmarcPolicy(res *Result) string {
+	if res.DMARC.Fallback {
+		return res.DMARC.Tags.SP
+	}
+	return res.DMARC.Tags.P
+}
+
+func (p *posture) checkMTASTS(res *Result, errs []string, status zdns.Status, err error) {
+	if res.NullMX {
+		return
+	}
+	if miekg.LookupFailed(status) {
+		p.add("mta-sts", SeverityMedium, "MTA-STS lookup failed: %s", miekg.LookupError(status, err))
+		return
+	}
+	if res.MTASTS == nil {
+		p.add("mta-sts", SeverityLow, "no MTA-STS record")
+	} else {
+		for _, e := range res.MTASTS.Errors {
+			p.add("mta-sts", SeverityMedium, "%s", e)
+		}
+	}
+	if policy := res.MTASTSPolicy; policy != nil {
+		for _, e := range policy.Errors {
+			p.add("mta-sts", SeverityMedium, "policy: %s", e)
+		}
+		if policy.Mode == "testing" || policy.Mode == "none" {
+			p.add("mta-sts", SeverityLow, "the MTA-STS policy mode is %s", policy.Mode)
+		}
+	}
+	for _, e := range errs {
+		p.add("mta-sts", SeverityMedium, "%s", e)
+	}
+	if res.TLSRPT == nil {
+		p.add("tls-rpt", SeverityLow, "no TLS-RPT record")
+	} else {
+		for _, e := range res.TLSRPT.Errors {
+			p.add("tls-rpt", SeverityLow, "%s", e)
+		}
+	}
+}
+
+// checkDANE checks the TLSA records of the MX hosts. errs holds the TLSA
+// lookups that failed, by MX host.
+func (p *posture) checkDANE(res *Result, errs map[string]string) {
+	for _, mx := range res.MX {
+		switch {
+		case errs[mx.Name] != "":
+			p.add("dane", SeverityMedium, "TLSA lookup failed for MX host %s: %s", mx.Name, errs[mx.Name])
+		case len(mx.TLSA) == 0:
+			p.add("dane", SeverityLow, "no TLSA records for MX host %s", mx.Name)
+		case !mx.TLSASecure:
+			p.add("dane", SeverityMedium, "TLSA records of MX host %s are not DNSSEC-secure", mx.Name)
+		case !usableTLSA(mx):
+			p.add("dane", SeverityMedium, "no usable TLSA records for MX host %s", mx.Name)
+		}
+	}
+}
+
+// usableTLSA is whether senders can authenticate mx with DANE: it has usable
+// TLSA records, which are DNSSEC-secure
+func usableTLSA(mx MXHost) bool {
+	if !mx.TLSASecure {
+		return false
+	}
+	for _, rec := range mx.TLSA {
+		if rec.Error == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// summarize returns the outcome of the checks, and the score of findings
+func summarize(res *Result, findings []Finding) Summary {
+	sum := Summary{Score: 100, AcceptsMail: !res.NullMX}
+	for _, f := range findings {
+		sum.Score -= penalties[f.Severity]
+	}
+	if sum.Score < 0 {
+		sum.Score = 0
+	}
+	if res.SPF.Record.Text != "" {
+		sum.SPFAll = spfAll(res.SPF.Record)
+	}
+	if res.DMARC != nil {
+		sum.DMARCPolicy = dmarcPolicy(res)
+	}
+	sum.MTASTS = res.MTASTS != nil
+	if res.MTASTSPolicy != nil {
+		sum.MTASTSMode = res.MTASTSPolicy.Mode
+	}
+	sum.TLSRPT = res.TLSRPT != nil
+	sum.DANE = len(res.MX) > 0
+	for _, mx := range res.MX {
+		sum.DANE = sum.DANE && usableTLSA(mx)
+	}
+	return sum
+}
@@ -231,9 +231,9 @@ type TALINKAnswer struct {
 
 type URIAnswer struct {
 	Answer
-	Priority uint16 `json:"previous_name" groups:"short,normal,long,trace"`
-	Weight   uint16 `json:"previous_name" groups:"short,normal,long,trace"`
-	Target   string `json:"previous_name" groups:"short,normal,long,trace"`
+	Priority uint16 `json:"priority" groups:"short,normal,long,trace"`
+	Weight   uint16 `json:"weight" groups:"short,normal,long,trace"`
+	Target   string `json:"target" groups:"short,normal,long,trace"`
 }
 
 // copy-paste from zmap/dns/types.go >>>>>
@@ -746,6 +746,13 @@ func ParseAnswer(ans dns.RR) interface{} {
 			PreviousName: cAns.PreviousName,
 			NextName:     cAns.NextName,
 		}
+	case *dns.URI:
+		return URIAnswer{
+			Answer:   makeBaseAnswer(&cAns.Hdr, ""),
+			Priority: cAns.Priority,
+			Weight:   cAns.Weight,
+			Target:   cAns.Target,
+		}
 	case *dns.L32:
 		return PrefAnswer{
 			Answer:     makeBaseAnswer(&cAns.Hdr, cAns.Locator32.String()),
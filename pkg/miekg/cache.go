@@ -7,6 +7,8 @@ import (
 
 	"github.com/zmap/dns"
 	"github.com/zmap/zdns/cachehash"
+	"github.com/zmap/zdns/internal/metrics"
+	"github.com/zmap/zdns/pkg/zdns"
 )
 
 type IsCached bool
@@ -28,6 +30,19 @@ func (s *Cache) Init(cacheSize int) {
 	s.IterativeCache.Init(cacheSize, 4096)
 }
 
+// sharedCache returns the iterative cache shared by all modules in the process
+func sharedCache(c *zdns.GlobalConf) *Cache {
+	create := func() interface{} {
+		cache := new(Cache)
+		cache.Init(c.CacheSize)
+		return cache
+	}
+	if c.Shared == nil {
+		return create().(*Cache)
+	}
+	return c.Shared.Get("miekg.IterativeCache", create).(*Cache)
+}
+
 func (s *Cache) VerboseGlobalLog(depth int, threadID int, args ...interface{}) {
 	log.Debug(makeVerbosePrefix(depth, threadID), args)
 }
@@ -84,6 +99,7 @@ func (s *Cache) GetCachedResult(q Question, isAuthCheck bool, depth int, threadI
 	if !ok { // nothing found
 		s.VerboseGlobalLog(depth+2, threadID, "-> no entry found in cache")
 		s.IterativeCache.Unlock(q)
+		metrics.CacheHit("iterative", false)
 		return retv, false
 	}
 	retv.Authorities = make([]interface{}, 0)
@@ -116,11 +132,13 @@ func (s *Cache) GetCachedResult(q Question, isAuthCheck bool, depth int, threadI
 	// Don't return an empty response.
 	if len(retv.Answers) == 0 && len(retv.Authorities) == 0 && len(retv.Additional) == 0 {
 		s.VerboseGlobalLog(depth+2, threadID, "-> no entry found in cache, after expiration")
+		metrics.CacheHit("iterative", false)
 		var emptyRetv Result
 		return emptyRetv, false
 	}
 
 	s.VerboseGlobalLog(depth+2, threadID, "Cache hit: ", retv)
+	metrics.CacheHit("iterative", true)
 	return retv, true
 }
 
@@ -0,0 +1,62 @@
+package miekg
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+// serveA answers A queries for example.com with 192.0.2.2, authoritatively
+func serveA(t *testing.T) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Authoritative = true
+		rr, _ := dns.NewRR("example.com. 60 IN A 192.0.2.2")
+		m.Answer = append(m.Answer, rr)
+		w.WriteMsg(m)
+	})}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func TestCachedRetryingLookupOptions(t *testing.T) {
+	nameServer := serveA(t)
+	l := directLookup(&zdns.GlobalConf{LocalAddrs: []net.IP{net.ParseIP("127.0.0.1")}, Timeout: 2 * time.Second})
+	cache := new(Cache)
+	cache.Init(100)
+	l.Factory.Factory.IterativeCache = cache
+	l.IterativeStop = time.Now().Add(time.Minute)
+
+	q := Question{Name: "example.com", Type: dns.TypeA, Class: dns.ClassINET}
+	cache.AddCachedAnswer(Answer{Name: "example.com", Type: "A", RrType: dns.TypeA, Class: "IN", RrClass: dns.ClassINET, Ttl: 60, Answer: "192.0.2.1"}, 0, 0)
+	res, isCached, status, err := l.cachedRetryingLookup(q, nameServer, "com", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	assert.Assert(t, bool(isCached))
+	assert.Equal(t, res.Answers[0].(Answer).Answer, "192.0.2.1")
+
+	// answers with the DO bit or a client subnet may differ from the cached
+	// ones, and aren't cached in turn
+	for _, opts := range []zdns.QueryOptions{
+		{DNSSEC: true},
+		{ClientSubnet: &net.IPNet{IP: net.ParseIP("198.51.100.0").To4(), Mask: net.CIDRMask(24, 32)}},
+	} {
+		assert.NilError(t, l.SetQueryOptions(opts))
+		res, isCached, status, err = l.cachedRetryingLookup(q, nameServer, "com", 0)
+		assert.NilError(t, err)
+		assert.Equal(t, status, zdns.STATUS_NOERROR)
+		assert.Assert(t, !bool(isCached))
+		assert.Equal(t, res.Answers[0].(Answer).Answer, "192.0.2.2")
+	}
+	assert.NilError(t, l.SetQueryOptions(zdns.QueryOptions{}))
+	res, _, _, _ = l.cachedRetryingLookup(q, nameServer, "com", 0)
+	assert.Equal(t, res.Answers[0].(Answer).Answer, "192.0.2.1")
+}
@@ -0,0 +1,60 @@
+package miekg
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+// serveSOA answers SOA queries for example.com on the IPv6 loopback address
+func serveSOA(t *testing.T) string {
+	pc, err := net.ListenPacket("udp", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 is unavailable: ", err)
+	}
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Authoritative = true
+		rr, _ := dns.NewRR("example.com. 60 IN SOA ns.example.com. hostmaster.example.com. 7 3600 600 86400 300")
+		m.Answer = append(m.Answer, rr)
+		w.WriteMsg(m)
+	})}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func directLookup(gc *zdns.GlobalConf) *Lookup {
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+	rlf := &RoutineLookupFactory{Factory: glf}
+	rlf.Initialize(gc)
+	l := new(Lookup)
+	l.Initialize("", dns.TypeSOA, dns.ClassINET, rlf)
+	return l
+}
+
+func TestDirectLookupOtherFamily(t *testing.T) {
+	nameServer := serveSOA(t)
+	q := Question{Name: "example.com", Type: dns.TypeSOA, Class: dns.ClassINET}
+	gc := &zdns.GlobalConf{LocalAddrs: []net.IP{net.ParseIP("127.0.0.1")}, Timeout: 2 * time.Second}
+
+	// the thread is bound to an IPv4 address, and no local address was given
+	res, _, status, err := directLookup(gc).DoDirectLookup(q, nameServer)
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	assert.Assert(t, res.(Result).Flags.Authoritative)
+	assert.Assert(t, !res.(Result).Flags.RecursionDesired)
+	assert.Equal(t, res.(Result).Answers[0].(SOAAnswer).Serial, uint32(7))
+
+	// --local-addr only has IPv4 addresses
+	gc.LocalAddrSpecified = true
+	_, _, status, err = directLookup(gc).DoDirectLookup(q, nameServer)
+	assert.Equal(t, status, zdns.STATUS_ERROR)
+	assert.ErrorContains(t, err, "no IPv6 local address")
+}
@@ -0,0 +1,93 @@
+package miekg
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/internal/metrics"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+// queryCount is the number of queries observed by the duration histogram
+func queryCount(t *testing.T, protocol string) uint64 {
+	var m dto.Metric
+	assert.NilError(t, metrics.QueryDuration.WithLabelValues(protocol).(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestCacheMetrics(t *testing.T) {
+	hits := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("iterative", "hit"))
+	misses := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("iterative", "miss"))
+
+	cache := new(Cache)
+	cache.Init(10)
+	q := Question{Name: "example.com", Type: dns.TypeA, Class: dns.ClassINET}
+	_, ok := cache.GetCachedResult(q, false, 0, 0)
+	assert.Assert(t, !ok)
+	cache.AddCachedAnswer(Answer{Name: "example.com", RrType: dns.TypeA, RrClass: dns.ClassINET, Ttl: 60, Answer: "192.0.2.1"}, 0, 0)
+	_, ok = cache.GetCachedResult(q, false, 0, 0)
+	assert.Assert(t, ok)
+
+	assert.Equal(t, testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("iterative", "hit")), hits+1)
+	assert.Equal(t, testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("iterative", "miss")), misses+1)
+}
+
+// serveTruncating serves example.com over UDP, with the TC bit set, and over
+// TCP, on the same port of the loopback address
+func serveTruncating(t *testing.T) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	l, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		pc.Close()
+		t.Skip("unable to listen on the same port over TCP: ", err)
+	}
+	handler := func(truncate bool) dns.HandlerFunc {
+		return func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+			m.Truncated = truncate
+			if !truncate {
+				rr, _ := dns.NewRR("example.com. 60 IN A 192.0.2.1")
+				m.Answer = append(m.Answer, rr)
+			}
+			w.WriteMsg(m)
+		}
+	}
+	udp := &dns.Server{PacketConn: pc, Handler: handler(true)}
+	tcp := &dns.Server{Listener: l, Handler: handler(false)}
+	go udp.ActivateAndServe()
+	go tcp.ActivateAndServe()
+	t.Cleanup(func() {
+		udp.Shutdown()
+		tcp.Shutdown()
+	})
+	return pc.LocalAddr().String()
+}
+
+func TestTCPFallbackMetrics(t *testing.T) {
+	nameServer := serveTruncating(t)
+	fallbacks := testutil.ToFloat64(metrics.TCPFallbacks)
+	udpQueries, tcpQueries := queryCount(t, "udp"), queryCount(t, "tcp")
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NilError(t, err)
+	defer conn.Close()
+	udp := &dns.Client{Timeout: 2 * time.Second}
+	tcp := &dns.Client{Net: "tcp", Timeout: 2 * time.Second}
+	res, status, err := DoLookupWorker(udp, tcp, &dns.Conn{Conn: conn}, Question{Name: "example.com", Type: dns.TypeA, Class: dns.ClassINET}, nameServer, true)
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	assert.Equal(t, res.Protocol, "tcp")
+	assert.Equal(t, len(res.Answers), 1)
+
+	assert.Equal(t, testutil.ToFloat64(metrics.TCPFallbacks), fallbacks+1)
+	assert.Equal(t, queryCount(t, "udp"), udpQueries+1)
+	assert.Equal(t, queryCount(t, "tcp"), tcpQueries+1)
+}
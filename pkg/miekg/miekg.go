@@ -2,6 +2,7 @@ package miekg
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"regexp"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/zmap/dns"
 	"github.com/zmap/go-iptree/blacklist"
+	"github.com/zmap/zdns/internal/metrics"
 	"github.com/zmap/zdns/pkg/zdns"
 )
 
@@ -47,6 +49,8 @@ type Result struct {
 type IpResult struct {
 	IPv4Addresses []string `json:"ipv4_addresses,omitempty" groups:"short,normal,long,trace"`
 	IPv6Addresses []string `json:"ipv6_addresses,omitempty" groups:"short,normal,long,trace"`
+	// the CNAMEs followed from the name, in order
+	CNAMEs []string `json:"-"`
 }
 
 type TraceStep struct {
@@ -72,7 +76,7 @@ func (s *Lookup) VerboseLog(depth int, args ...interface{}) {
 
 type GlobalLookupFactory struct {
 	zdns.BaseGlobalLookupFactory
-	IterativeCache Cache
+	IterativeCache *Cache
 	DNSType        uint16
 	DNSClass       uint16
 	BlacklistPath  string
@@ -110,7 +114,7 @@ func (s *GlobalLookupFactory) Initialize(c *zdns.GlobalConf) error {
 	if err != nil {
 		return err
 	}
-	s.IterativeCache.Init(c.CacheSize)
+	s.IterativeCache = sharedCache(c)
 	s.DNSClass = dns.ClassINET
 	return nil
 }
@@ -148,6 +152,10 @@ type RoutineLookupFactory struct {
 	Conn                *dns.Conn
 	ThreadID            int
 	PrefixRegexp        *regexp.Regexp
+	// the clients and socket of DoDirectLookup for name servers of the other
+	// address family than LocalAddr, created when first needed
+	otherFamily    *RoutineLookupFactory
+	otherFamilyErr error
 }
 
 func (s *RoutineLookupFactory) Initialize(c *zdns.GlobalConf) {
@@ -180,7 +188,7 @@ func (s *RoutineLookupFactory) Initialize(c *zdns.GlobalConf) {
 		}
 	}
 	// create PacketConn for use throughout thread's life
-	conn, err := net.ListenUDP("udp", &net.UDPAddr{s.LocalAddr, 0, ""})
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: s.LocalAddr})
 	if err != nil {
 		log.Fatal("unable to create socket", err)
 	}
@@ -200,6 +208,82 @@ func (s *RoutineLookupFactory) Initialize(c *zdns.GlobalConf) {
 	s.DNSClass = c.Class
 }
 
+// otherFamilyFactory returns a copy of the factory with clients and a socket
+// bound to a local address of the other address family than LocalAddr
+func (s *RoutineLookupFactory) otherFamilyFactory() (*RoutineLookupFactory, error) {
+	if s.otherFamily != nil || s.otherFamilyErr != nil {
+		return s.otherFamily, s.otherFamilyErr
+	}
+	ipv4 := s.LocalAddr.To4() == nil
+	local := familyLocalAddr(s.Factory.GlobalConf, ipv4)
+	if local == nil {
+		family := "IPv6"
+		if ipv4 {
+			family = "IPv4"
+		}
+		s.otherFamilyErr = fmt.Errorf("no %s local address to query the name server from (see --local-addr)", family)
+		return nil, s.otherFamilyErr
+	}
+	f := *s
+	f.LocalAddr = local
+	if s.Client != nil {
+		f.Client = new(dns.Client)
+		f.Client.Timeout = s.Client.Timeout
+		f.Client.Dialer = &net.Dialer{
+			Timeout:   s.Timeout,
+			LocalAddr: &net.UDPAddr{IP: local},
+		}
+	}
+	if s.TCPClient != nil {
+		f.TCPClient = new(dns.Client)
+		f.TCPClient.Net = "tcp"
+		f.TCPClient.Timeout = s.TCPClient.Timeout
+		f.TCPClient.Dialer = &net.Dialer{
+			Timeout:   s.Timeout,
+			LocalAddr: &net.TCPAddr{IP: local},
+		}
+	}
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: local})
+	if err != nil {
+		s.otherFamilyErr = err
+		return nil, err
+	}
+	f.Conn = new(dns.Conn)
+	f.Conn.Conn = conn
+	s.otherFamily = &f
+	return s.otherFamily, nil
+}
+
+// familyLocalAddr returns the local address of queries to name servers of
+// one address family: the first local address of the family, or, if no local
+// address was given, the unspecified address, for the kernel to pick one. It
+// is nil if the local addresses given are all of the other family.
+func familyLocalAddr(c *zdns.GlobalConf, ipv4 bool) net.IP {
+	for _, ip := range c.LocalAddrs {
+		if (ip.To4() != nil) == ipv4 {
+			return ip
+		}
+	}
+	if c.LocalAddrSpecified {
+		return nil
+	}
+	if ipv4 {
+		return net.IPv4zero
+	}
+	return net.IPv6unspecified
+}
+
+// otherFamily is whether nameServer is of the other address family than the
+// local address, which a socket bound to it can't send to
+func otherFamily(local net.IP, nameServer string) bool {
+	host, _, err := net.SplitHostPort(nameServer)
+	if err != nil || local == nil || local.IsUnspecified() {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.To4() != nil) != (local.To4() != nil)
+}
+
 func (s *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
 	a := Lookup{Factory: s}
 	nameServer := s.Factory.RandomNameServer()
@@ -215,6 +299,7 @@ type Lookup struct {
 	DNSClass      uint16
 	NameServer    string
 	IterativeStop time.Time
+	Options       zdns.QueryOptions
 
 	Conn *dns.Conn
 }
@@ -229,8 +314,42 @@ func (s *Lookup) Initialize(nameServer string, dnsType uint16, dnsClass uint16,
 	return nil
 }
 
+// SetQueryOptions applies per-query options from structured input. Any
+// class given overrides the class of the module.
+func (s *Lookup) SetQueryOptions(opts zdns.QueryOptions) error {
+	if opts.Class != 0 {
+		s.DNSClass = opts.Class
+	}
+	s.Options = opts
+	return nil
+}
+
 func (s *Lookup) doLookup(q Question, nameServer string, recursive bool) (Result, zdns.Status, error) {
-	return DoLookupWorker(s.Factory.Client, s.Factory.TCPClient, s.Conn, q, nameServer, recursive)
+	return doLookupWorker(s.Factory.Client, s.Factory.TCPClient, s.Conn, q, nameServer, recursive, s.Options)
+}
+
+// setEDNSOptions adds an OPT record carrying the EDNS parts of opts to m
+func setEDNSOptions(m *dns.Msg, opts zdns.QueryOptions) {
+	if opts.ClientSubnet == nil && !opts.DNSSEC {
+		return
+	}
+	m.SetEdns0(4096, opts.DNSSEC)
+	if opts.ClientSubnet != nil {
+		ones, _ := opts.ClientSubnet.Mask.Size()
+		e := &dns.EDNS0_SUBNET{
+			Code:          dns.EDNS0SUBNET,
+			SourceNetmask: uint8(ones),
+		}
+		if ip4 := opts.ClientSubnet.IP.To4(); ip4 != nil {
+			e.Family = 1
+			e.Address = ip4
+		} else {
+			e.Family = 2
+			e.Address = opts.ClientSubnet.IP
+		}
+		opt := m.IsEdns0()
+		opt.Option = append(opt.Option, e)
+	}
 }
 
 // CheckTxtRecords common function for all modules based on search in TXT record
@@ -261,6 +380,10 @@ func (s *Lookup) FindTxtRecord(res Result) (string, error) {
 
 // Expose the inner logic so other tools can use it
 func DoLookupWorker(udp *dns.Client, tcp *dns.Client, conn *dns.Conn, q Question, nameServer string, recursive bool) (Result, zdns.Status, error) {
+	return doLookupWorker(udp, tcp, conn, q, nameServer, recursive, zdns.QueryOptions{})
+}
+
+func doLookupWorker(udp *dns.Client, tcp *dns.Client, conn *dns.Conn, q Question, nameServer string, recursive bool, opts zdns.QueryOptions) (Result, zdns.Status, error) {
 	res := Result{Answers: []interface{}{}, Authorities: []interface{}{}, Additional: []interface{}{}}
 	res.Resolver = nameServer
 
@@ -268,18 +391,22 @@ func DoLookupWorker(udp *dns.Client, tcp *dns.Client, conn *dns.Conn, q Question
 	m.SetQuestion(dotName(q.Name), q.Type)
 	m.Question[0].Qclass = q.Class
 	m.RecursionDesired = recursive
+	setEDNSOptions(m, opts)
 
 	var r *dns.Msg
 	var err error
+	start := time.Now()
 	if udp != nil {
 		res.Protocol = "udp"
 
 		dst, _ := net.ResolveUDPAddr("udp", nameServer)
 		r, _, err = udp.ExchangeWithConnTo(m, conn, dst)
+		metrics.QueryDuration.WithLabelValues(res.Protocol).Observe(time.Since(start).Seconds())
 		// if record comes back truncated, but we have a TCP connection, try again with that
 		if r != nil && (r.Truncated || r.Rcode == dns.RcodeBadTrunc) {
 			if tcp != nil {
-				return DoLookupWorker(nil, tcp, conn, q, nameServer, recursive)
+				metrics.TCPFallbacks.Inc()
+				return doLookupWorker(nil, tcp, conn, q, nameServer, recursive, opts)
 			} else {
 				return res, zdns.STATUS_TRUNCATED, err
 			}
@@ -287,6 +414,7 @@ func DoLookupWorker(udp *dns.Client, tcp *dns.Client, conn *dns.Conn, q Question
 	} else {
 		res.Protocol = "tcp"
 		r, _, err = tcp.Exchange(m, nameServer)
+		metrics.QueryDuration.WithLabelValues(res.Protocol).Observe(time.Since(start).Seconds())
 	}
 	if err != nil || r == nil {
 		if nerr, ok := err.(net.Error); ok {
@@ -379,6 +507,7 @@ func (s *Lookup) retryingLookup(q Question, nameServer string, recursive bool) (
 			}
 			return result, status, err
 		}
+		metrics.Retries.Inc()
 		if s.Factory.Client != nil {
 			s.Factory.Client.Timeout = 2 * s.Factory.Client.Timeout
 		}
@@ -398,11 +527,18 @@ func (s *Lookup) cachedRetryingLookup(q Question, nameServer, layer string, dept
 		var r Result
 		return r, isCached, zdns.STATUS_ITER_TIMEOUT, nil
 	}
+	// The cache is keyed by question only, but the answers to queries with a
+	// client subnet or the DO bit can differ from those without, so such
+	// queries neither use cached answers nor add theirs to the cache. The
+	// delegations they follow don't depend on the options.
+	useCache := s.Options.ClientSubnet == nil && !s.Options.DNSSEC
 	// First, we check the answer
-	cachedResult, ok := s.Factory.Factory.IterativeCache.GetCachedResult(q, false, depth+1, s.Factory.ThreadID)
-	if ok {
-		isCached = true
-		return cachedResult, isCached, zdns.STATUS_NOERROR, nil
+	if useCache {
+		cachedResult, ok := s.Factory.Factory.IterativeCache.GetCachedResult(q, false, depth+1, s.Factory.ThreadID)
+		if ok {
+			isCached = true
+			return cachedResult, isCached, zdns.STATUS_NOERROR, nil
+		}
 	}
 
 	nameServerIP, _, err := net.SplitHostPort(nameServer)
@@ -416,6 +552,7 @@ func (s *Lookup) cachedRetryingLookup(q Question, nameServer, layer string, dept
 			return r, isCached, zdns.STATUS_ERROR, err
 		} else if blacklisted {
 			s.Factory.Factory.BlMu.Unlock()
+			metrics.BlacklistHits.Inc()
 			s.VerboseLog(depth+2, "Hit blacklisted nameserver ", q.Name, ", Layer: ", layer, ", Nameserver: ", nameServer)
 			var r Result
 			return r, isCached, zdns.STATUS_BLACKLIST, nil
@@ -443,7 +580,7 @@ func (s *Lookup) cachedRetryingLookup(q Question, nameServer, layer string, dept
 		qAuth.Name = authName
 		qAuth.Type = dns.TypeNS
 		qAuth.Class = dns.ClassINET
-		cachedResult, ok = s.Factory.Factory.IterativeCache.GetCachedResult(qAuth, true, depth+2, s.Factory.ThreadID)
+		cachedResult, ok := s.Factory.Factory.IterativeCache.GetCachedResult(qAuth, true, depth+2, s.Factory.ThreadID)
 		if ok {
 			isCached = true
 			return cachedResult, isCached, zdns.STATUS_NOERROR, nil
@@ -454,7 +591,9 @@ func (s *Lookup) cachedRetryingLookup(q Question, nameServer, layer string, dept
 	s.VerboseLog(depth+2, "Wire lookup for name: ", q.Name, " (", q.Type, ") at nameserver: ", nameServer)
 	result, status, err := s.retryingLookup(q, nameServer, false)
 
-	s.Factory.Factory.IterativeCache.CacheUpdate(layer, result, depth+2, s.Factory.ThreadID)
+	if useCache {
+		s.Factory.Factory.IterativeCache.CacheUpdate(layer, result, depth+2, s.Factory.ThreadID)
+	}
 	return result, isCached, status, err
 }
 
@@ -687,6 +826,30 @@ func (s *Lookup) DoMiekgLookup(q Question, nameServer string) (interface{}, zdns
 	}
 }
 
+// DoDirectLookup sends q to nameServer without asking it to recurse (RD=0),
+// e.g., to query an authoritative server, even with --iterative. Name servers
+// of the other address family than the local address of the thread (e.g.,
+// the IPv6 addresses of NS records) are queried from a local address of
+// their family, or fail if --local-addr has none.
+func (s *Lookup) DoDirectLookup(q Question, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	if q.Type == 0 {
+		q.Type = s.DNSType
+	}
+	if q.Class == 0 {
+		q.Class = s.DNSClass
+	}
+	if otherFamily(s.Factory.LocalAddr, nameServer) {
+		f, err := s.Factory.otherFamilyFactory()
+		if err != nil {
+			return nil, nil, zdns.STATUS_ERROR, err
+		}
+		l := *s
+		l.Factory, l.Conn = f, f.Conn
+		return l.tracedRetryingLookup(q, nameServer, false)
+	}
+	return s.tracedRetryingLookup(q, nameServer, false)
+}
+
 func populateResults(records []interface{}, dnsType uint16, candidateSet map[string][]Answer, cnameSet map[string][]Answer, garbage map[string][]Answer) {
 	for _, a := range records {
 		// filter only valid answers of requested type or CNAME (#163)
@@ -757,6 +920,22 @@ func (s *Lookup) DoIpsLookup(lc LookupClient, name string, nameServer string, dn
 	}
 }
 
+// cnameChain returns the targets of the CNAMEs followed from name by
+// DoIpsLookup
+func cnameChain(name string, cnameSet map[string][]Answer) []string {
+	var chain []string
+	// DoIpsLookup follows at most 10 CNAMEs
+	for len(chain) <= 10 {
+		res, ok := cnameSet[name]
+		if !ok || len(res) == 0 {
+			break
+		}
+		name = strings.ToLower(strings.TrimSuffix(res[0].Answer, "."))
+		chain = append(chain, name)
+	}
+	return chain
+}
+
 func (s *Lookup) DoTargetedLookup(l LookupClient, name, nameServer string, lookupIpv4 bool, lookupIpv6 bool) (interface{}, []interface{}, zdns.Status, error) {
 	res := IpResult{}
 	candidateSet := map[string][]Answer{}
@@ -774,6 +953,7 @@ func (s *Lookup) DoTargetedLookup(l LookupClient, name, nameServer string, looku
 			res.IPv4Addresses = make([]string, len(ipv4))
 			copy(res.IPv4Addresses, ipv4)
 		}
+		res.CNAMEs = cnameChain(name, cnameSet)
 	}
 	candidateSet = map[string][]Answer{}
 	cnameSet = map[string][]Answer{}
@@ -783,6 +963,9 @@ func (s *Lookup) DoTargetedLookup(l LookupClient, name, nameServer string, looku
 			res.IPv6Addresses = make([]string, len(ipv6))
 			copy(res.IPv6Addresses, ipv6)
 		}
+		if res.CNAMEs == nil {
+			res.CNAMEs = cnameChain(name, cnameSet)
+		}
 	}
 
 	combinedTrace := append(ipv4Trace, ipv6Trace...)
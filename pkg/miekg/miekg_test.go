@@ -246,6 +246,9 @@ func TestCname(t *testing.T) {
 	}
 	res, _, _, _ := a.DoTargetedLookup(mc, "cname.example.com", gc.NameServers[0], true, false)
 	verifyResult(t, res.(IpResult), []string{"192.0.2.1"}, nil)
+	if cnames := res.(IpResult).CNAMEs; !reflect.DeepEqual(cnames, []string{"example.com"}) {
+		t.Errorf("Expected the CNAME chain [example.com], found %v", cnames)
+	}
 }
 
 // Test CName with lookupIpv6 as true returns ipv6 addresses
@@ -618,6 +621,36 @@ func TestParseAnswer(t *testing.T) {
 		t.Errorf("Unxpected replacement. Expected %v, got %v", ".", answer.Replacement)
 	}
 
+	rr = &dns.URI{
+		Hdr: dns.RR_Header{
+			Name:     "_ftp._tcp.example.com",
+			Rrtype:   dns.TypeURI,
+			Class:    dns.ClassINET,
+			Ttl:      3600,
+			Rdlength: 0,
+		},
+		Priority: 10,
+		Weight:   1,
+		Target:   "ftp://ftp1.example.com/public",
+	}
+
+	res = ParseAnswer(rr)
+	uri, ok := res.(URIAnswer)
+	if !ok {
+		t.Error("Failed to parse record")
+		return
+	}
+	verifyAnswer(t, uri.Answer, rr, "")
+	if uri.Priority != 10 {
+		t.Errorf("Unxpected priority. Expected %v, got %v", 10, uri.Priority)
+	}
+	if uri.Weight != 1 {
+		t.Errorf("Unxpected weight. Expected %v, got %v", 1, uri.Weight)
+	}
+	if uri.Target != "ftp://ftp1.example.com/public" {
+		t.Errorf("Unxpected target. Expected %v, got %v", "ftp://ftp1.example.com/public", uri.Target)
+	}
+
 	// TODO: test remaining RR types
 }
 
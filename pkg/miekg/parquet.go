@@ -0,0 +1,288 @@
+package miekg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"github.com/zmap/zdns/iohandlers"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+func init() {
+	zdns.RegisterOutputFormat("parquet", zdns.OutputFormat{New: newParquetEncoder, Compressed: true})
+}
+
+// parquetRow is the Parquet schema of a result. It follows zdns.Result, with
+// the fields of Result (the data of raw lookups), IpResult (ALOOKUP) and the
+// servers of MXLOOKUP and NSLOOKUP as columns. Data of other modules is kept
+// as JSON. With --modules, there is a row for every module.
+type parquetRow struct {
+	Name          string          `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AlteredName   *string         `parquet:"name=altered_name, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Module        *string         `parquet:"name=module, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Nameserver    *string         `parquet:"name=nameserver, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Class         *string         `parquet:"name=class, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	AlexaRank     *int64          `parquet:"name=alexa_rank, type=INT64, repetitiontype=OPTIONAL"`
+	Metadata      *string         `parquet:"name=metadata, type=BYTE_ARRAY, convertedtype=JSON, repetitiontype=OPTIONAL"`
+	Status        string          `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Error         *string         `parquet:"name=error, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Timestamp     *string         `parquet:"name=timestamp, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Protocol      *string         `parquet:"name=protocol, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Resolver      *string         `parquet:"name=resolver, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Flags         *parquetFlags   `parquet:"name=flags, repetitiontype=OPTIONAL"`
+	Answers       []parquetAnswer `parquet:"name=answers, repetitiontype=REPEATED"`
+	Authorities   []parquetAnswer `parquet:"name=authorities, repetitiontype=REPEATED"`
+	Additionals   []parquetAnswer `parquet:"name=additionals, repetitiontype=REPEATED"`
+	IPv4Addresses []string        `parquet:"name=ipv4_addresses, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+	IPv6Addresses []string        `parquet:"name=ipv6_addresses, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+	Servers       []parquetServer `parquet:"name=servers, repetitiontype=REPEATED"`
+	Data          *string         `parquet:"name=data, type=BYTE_ARRAY, convertedtype=JSON, repetitiontype=OPTIONAL"`
+}
+
+type parquetFlags struct {
+	Response           bool  `parquet:"name=response, type=BOOLEAN"`
+	Opcode             int32 `parquet:"name=opcode, type=INT32"`
+	Authoritative      bool  `parquet:"name=authoritative, type=BOOLEAN"`
+	Truncated          bool  `parquet:"name=truncated, type=BOOLEAN"`
+	RecursionDesired   bool  `parquet:"name=recursion_desired, type=BOOLEAN"`
+	RecursionAvailable bool  `parquet:"name=recursion_available, type=BOOLEAN"`
+	Authenticated      bool  `parquet:"name=authenticated, type=BOOLEAN"`
+	CheckingDisabled   bool  `parquet:"name=checking_disabled, type=BOOLEAN"`
+	ErrorCode          int32 `parquet:"name=error_code, type=INT32"`
+}
+
+// parquetAnswer holds the fields of Answer, which all answers share. The
+// type-specific fields of the other answer structs (e.g., the preference of
+// MX or the fields of SOA) are in rdata, in zone-file format, or, for
+// answers that can't be written that way, in rdata_json as the JSON answer.
+type parquetAnswer struct {
+	Name      string  `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Type      string  `parquet:"name=type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Class     string  `parquet:"name=class, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TTL       *int64  `parquet:"name=ttl, type=INT64, repetitiontype=OPTIONAL"`
+	Answer    *string `parquet:"name=answer, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Rdata     *string `parquet:"name=rdata, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	RdataJSON *string `parquet:"name=rdata_json, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+// parquetServer is an MX exchange (MXLOOKUP) or name server (NSLOOKUP)
+type parquetServer struct {
+	Name          string   `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Type          string   `parquet:"name=type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Preference    *int32   `parquet:"name=preference, type=INT32, repetitiontype=OPTIONAL"`
+	TTL           *int64   `parquet:"name=ttl, type=INT64, repetitiontype=OPTIONAL"`
+	IPv4Addresses []string `parquet:"name=ipv4_addresses, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+	IPv6Addresses []string `parquet:"name=ipv6_addresses, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+}
+
+// parquetResult holds the JSON fields of a result that are written to Parquet
+type parquetResult struct {
+	AlteredName string                    `json:"altered_name"`
+	Name        string                    `json:"name"`
+	Nameserver  string                    `json:"nameserver"`
+	Class       string                    `json:"class"`
+	AlexaRank   int64                     `json:"alexa_rank"`
+	Metadata    json.RawMessage           `json:"metadata"`
+	Status      string                    `json:"status"`
+	Error       string                    `json:"error"`
+	Timestamp   string                    `json:"timestamp"`
+	Data        json.RawMessage           `json:"data"`
+	Results     map[string]*parquetResult `json:"results"`
+}
+
+// parquetData holds the JSON fields of the data of raw lookups, ALOOKUP,
+// MXLOOKUP and NSLOOKUP
+type parquetData struct {
+	textData
+	IPv4Addresses []string `json:"ipv4_addresses"`
+	IPv6Addresses []string `json:"ipv6_addresses"`
+	Exchanges     []struct {
+		Name          string   `json:"name"`
+		Type          string   `json:"type"`
+		Preference    *int32   `json:"preference"`
+		TTL           *int64   `json:"ttl"`
+		IPv4Addresses []string `json:"ipv4_addresses"`
+		IPv6Addresses []string `json:"ipv6_addresses"`
+	} `json:"exchanges"`
+	Servers []struct {
+		Name          string   `json:"name"`
+		Type          string   `json:"type"`
+		TTL           *int64   `json:"ttl"`
+		IPv4Addresses []string `json:"ipv4_addresses"`
+		IPv6Addresses []string `json:"ipv6_addresses"`
+	} `json:"servers"`
+}
+
+type parquetEncoder struct {
+	pw *writer.ParquetWriter
+}
+
+var parquetCodecs = map[string]parquet.CompressionCodec{
+	"":                         parquet.CompressionCodec_SNAPPY,
+	iohandlers.CompressionAuto: parquet.CompressionCodec_SNAPPY,
+	iohandlers.CompressionNone: parquet.CompressionCodec_UNCOMPRESSED,
+	iohandlers.CompressionGzip: parquet.CompressionCodec_GZIP,
+	iohandlers.CompressionZstd: parquet.CompressionCodec_ZSTD,
+}
+
+// newParquetEncoder creates the encoder of the parquet output format. Pages
+// are compressed with Snappy, or the codec given with --output-compression.
+// Rows are buffered in memory until a whole row group is written, so the size
+// of the file isn't known while it's written and --output-rotate-bytes can't
+// be honored.
+func newParquetEncoder(gc *zdns.GlobalConf) (iohandlers.EncoderFactory, error) {
+	if gc.OutputRotateBytes > 0 {
+		return nil, errors.New("--output-rotate-bytes is not supported with parquet output, use --output-rotate-records instead")
+	}
+	codec, ok := parquetCodecs[gc.OutputCompression]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression: %s", gc.OutputCompression)
+	}
+	return func(w io.Writer) (iohandlers.Encoder, error) {
+		pw, err := writer.NewParquetWriterFromWriter(w, new(parquetRow), 4)
+		if err != nil {
+			return nil, err
+		}
+		pw.CompressionType = codec
+		return &parquetEncoder{pw: pw}, nil
+	}, nil
+}
+
+func (e *parquetEncoder) Encode(result string) error {
+	var res parquetResult
+	if err := json.Unmarshal([]byte(result), &res); err != nil {
+		return err
+	}
+	if len(res.Results) == 0 {
+		return e.pw.Write(parquetRowOf(&res, ""))
+	}
+	modules := make([]string, 0, len(res.Results))
+	for m := range res.Results {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	for _, m := range modules {
+		sub := res.Results[m]
+		sub.Name, sub.AlteredName, sub.AlexaRank, sub.Metadata = res.Name, res.AlteredName, res.AlexaRank, res.Metadata
+		if err := e.pw.Write(parquetRowOf(sub, m)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the remaining rows and the footer of the file
+func (e *parquetEncoder) Close() error {
+	return e.pw.WriteStop()
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func parquetRowOf(res *parquetResult, module string) parquetRow {
+	row := parquetRow{
+		Name:        res.Name,
+		AlteredName: optionalString(res.AlteredName),
+		Module:      optionalString(module),
+		Nameserver:  optionalString(res.Nameserver),
+		Class:       optionalString(res.Class),
+		Metadata:    optionalString(string(res.Metadata)),
+		Status:      res.Status,
+		Error:       optionalString(res.Error),
+		Timestamp:   optionalString(res.Timestamp),
+	}
+	if res.AlexaRank != 0 {
+		row.AlexaRank = &res.AlexaRank
+	}
+	if len(res.Data) == 0 || string(res.Data) == "null" {
+		return row
+	}
+	var data parquetData
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		row.Data = optionalString(string(res.Data))
+		return row
+	}
+	known := false
+	if data.Answers != nil || data.Authorities != nil || data.Additional != nil || data.Resolver != "" {
+		known = true
+		row.Protocol = optionalString(data.Protocol)
+		row.Resolver = optionalString(data.Resolver)
+		if data.Flags != nil {
+			f := data.Flags
+			row.Flags = &parquetFlags{
+				Response:           f.Response,
+				Opcode:             int32(f.Opcode),
+				Authoritative:      f.Authoritative,
+				Truncated:          f.Truncated,
+				RecursionDesired:   f.RecursionDesired,
+				RecursionAvailable: f.RecursionAvailable,
+				Authenticated:      f.Authenticated,
+				CheckingDisabled:   f.CheckingDisabled,
+				ErrorCode:          int32(f.ErrorCode),
+			}
+		}
+		row.Answers = parquetAnswers(data.Answers)
+		row.Authorities = parquetAnswers(data.Authorities)
+		row.Additionals = parquetAnswers(data.Additional)
+	}
+	if data.IPv4Addresses != nil || data.IPv6Addresses != nil {
+		known = true
+		row.IPv4Addresses = data.IPv4Addresses
+		row.IPv6Addresses = data.IPv6Addresses
+	}
+	for _, s := range data.Exchanges {
+		known = true
+		row.Servers = append(row.Servers, parquetServer{s.Name, s.Type, s.Preference, s.TTL, s.IPv4Addresses, s.IPv6Addresses})
+	}
+	for _, s := range data.Servers {
+		known = true
+		row.Servers = append(row.Servers, parquetServer{s.Name, s.Type, nil, s.TTL, s.IPv4Addresses, s.IPv6Addresses})
+	}
+	if !known {
+		row.Data = optionalString(string(res.Data))
+	}
+	return row
+}
+
+func parquetAnswers(answers []json.RawMessage) []parquetAnswer {
+	var rows []parquetAnswer
+	for _, raw := range answers {
+		var a struct {
+			Name   string  `json:"name"`
+			Class  string  `json:"class"`
+			Ttl    *int64  `json:"ttl"`
+			Answer *string `json:"answer"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			continue
+		}
+		fields := rawFields(raw)
+		rrType, err := answerType(fields)
+		if err != nil {
+			continue
+		}
+		row := parquetAnswer{Name: a.Name, Type: rrType, Class: a.Class, TTL: a.Ttl, Answer: a.Answer}
+		if rdata, ok := rdataPresentation(rrType, raw, fields); ok {
+			row.Rdata = &rdata
+		} else {
+			row.RdataJSON = optionalString(string(raw))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func rawFields(raw json.RawMessage) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	json.Unmarshal(raw, &fields)
+	return fields
+}
@@ -0,0 +1,70 @@
+package miekg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+func TestParquetEncoder(t *testing.T) {
+	newEnc, err := newParquetEncoder(&zdns.GlobalConf{OutputCompression: "zstd"})
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	enc, err := newEnc(&b)
+	assert.NilError(t, err)
+	for _, res := range []string{
+		`{"name":"example.com","status":"NOERROR","metadata":{"id":1},"data":{"answers":[{"ttl":60,"type":"MX","class":"IN","name":"example.com","answer":"mail.example.com.","preference":10},{"ttl":60,"type":"CAA","class":"IN","name":"example.com","tag":"issue","value":"ca.example","flag":0},{"ttl":60,"type":2,"class":"IN","name":"example.com","algorithm":4,"fingerprint":"0123456789abcdef"}],"protocol":"udp","resolver":"192.0.2.53:53","flags":{"response":true}}}`,
+		`{"name":"example.net","status":"NXDOMAIN"}`,
+		`{"name":"example.org","results":{"MXLOOKUP":{"status":"NOERROR","data":{"exchanges":[{"name":"mx.example.org","type":"MX","preference":5,"ipv4_addresses":["192.0.2.1"]}]}},"SPF":{"status":"NOERROR","data":{"spf":"v=spf1 -all"}}}}`,
+	} {
+		assert.NilError(t, enc.Encode(res))
+	}
+	assert.NilError(t, enc.Close())
+
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(b.Bytes()), new(parquetRow), 1)
+	assert.NilError(t, err)
+	assert.Equal(t, pr.GetNumRows(), int64(4))
+	rows := make([]parquetRow, 4)
+	assert.NilError(t, pr.Read(&rows))
+	pr.ReadStop()
+
+	assert.Equal(t, rows[0].Name, "example.com")
+	assert.Equal(t, *rows[0].Metadata, `{"id":1}`)
+	assert.Equal(t, *rows[0].Resolver, "192.0.2.53:53")
+	assert.Assert(t, rows[0].Flags.Response)
+	assert.Equal(t, len(rows[0].Answers), 3)
+	assert.Equal(t, *rows[0].Answers[0].Answer, "mail.example.com.")
+	assert.Equal(t, *rows[0].Answers[0].Rdata, "10 mail.example.com.")
+	assert.Assert(t, rows[0].Answers[0].RdataJSON == nil)
+	assert.Equal(t, *rows[0].Answers[0].TTL, int64(60))
+	assert.Assert(t, rows[0].Answers[1].Answer == nil)
+	assert.Equal(t, *rows[0].Answers[1].Rdata, `0 issue "ca.example"`)
+	// answers that can't be written in zone-file format are kept as JSON in
+	// a column of their own
+	assert.Equal(t, rows[0].Answers[2].Type, "SSHFP")
+	assert.Assert(t, rows[0].Answers[2].Rdata == nil)
+	assert.Equal(t, *rows[0].Answers[2].RdataJSON, `{"ttl":60,"type":2,"class":"IN","name":"example.com","algorithm":4,"fingerprint":"0123456789abcdef"}`)
+
+	assert.Equal(t, rows[1].Status, "NXDOMAIN")
+	assert.Assert(t, rows[1].Flags == nil)
+	assert.Equal(t, len(rows[1].Answers), 0)
+
+	assert.Equal(t, *rows[2].Module, "MXLOOKUP")
+	assert.Equal(t, rows[2].Name, "example.org")
+	assert.Equal(t, rows[2].Servers[0].Name, "mx.example.org")
+	assert.Equal(t, *rows[2].Servers[0].Preference, int32(5))
+	assert.DeepEqual(t, rows[2].Servers[0].IPv4Addresses, []string{"192.0.2.1"})
+	assert.Equal(t, *rows[3].Module, "SPF")
+	assert.Equal(t, *rows[3].Data, `{"spf":"v=spf1 -all"}`)
+}
+
+func TestParquetEncoderRotateBytes(t *testing.T) {
+	_, err := newParquetEncoder(&zdns.GlobalConf{OutputRotateBytes: 1 << 20})
+	assert.ErrorContains(t, err, "--output-rotate-bytes")
+	_, err = newParquetEncoder(&zdns.GlobalConf{OutputRotateRecords: 1000})
+	assert.NilError(t, err)
+}
@@ -0,0 +1,389 @@
+package miekg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/zmap/zdns/iohandlers"
+	"github.com/zmap/zdns/pkg/zdns"
+	zdnsv1 "github.com/zmap/zdns/pkg/zdns/zdnspb/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func init() {
+	zdns.RegisterOutputFormat("protobuf", zdns.OutputFormat{New: func(gc *zdns.GlobalConf) (iohandlers.EncoderFactory, error) {
+		return func(w io.Writer) (iohandlers.Encoder, error) {
+			return &protobufEncoder{w: w}, nil
+		}, nil
+	}})
+}
+
+// protobufEncoder writes results as zdns.v1.Result messages, each preceded by
+// its length as a varint (like Java's writeDelimitedTo)
+type protobufEncoder struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (e *protobufEncoder) grow(n int) []byte {
+	if cap(e.buf) < n {
+		e.buf = make([]byte, n)
+	}
+	return e.buf[:n]
+}
+
+func (e *protobufEncoder) Encode(result string) error {
+	res, err := ResultProto([]byte(result))
+	if err != nil {
+		return err
+	}
+	size := proto.Size(res)
+	buf := e.grow(binary.MaxVarintLen64 + size)
+	n := binary.PutUvarint(buf, uint64(size))
+	b, err := proto.MarshalOptions{}.MarshalAppend(buf[:n], res)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *protobufEncoder) Close() error {
+	return nil
+}
+
+// protoResult holds the JSON fields of a result, keeping the parts whose
+// message depends on their shape as JSON
+type protoResult struct {
+	AlteredName string                     `json:"altered_name"`
+	Name        string                     `json:"name"`
+	Nameserver  string                     `json:"nameserver"`
+	Class       string                     `json:"class"`
+	AlexaRank   int64                      `json:"alexa_rank"`
+	Metadata    json.RawMessage            `json:"metadata"`
+	Status      string                     `json:"status"`
+	Error       string                     `json:"error"`
+	Timestamp   string                     `json:"timestamp"`
+	Data        json.RawMessage            `json:"data"`
+	Trace       []json.RawMessage          `json:"trace"`
+	Results     map[string]json.RawMessage `json:"results"`
+}
+
+var protojsonOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// ResultProto converts a result, as output in JSON, to its typed protobuf
+// form
+func ResultProto(result []byte) (*zdnsv1.Result, error) {
+	var r protoResult
+	if err := json.Unmarshal(result, &r); err != nil {
+		return nil, err
+	}
+	res := &zdnsv1.Result{
+		AlteredName: r.AlteredName,
+		Name:        r.Name,
+		Nameserver:  r.Nameserver,
+		Class:       r.Class,
+		AlexaRank:   r.AlexaRank,
+		Status:      r.Status,
+		Error:       r.Error,
+		Timestamp:   r.Timestamp,
+	}
+	if len(r.Metadata) > 0 {
+		res.Metadata = new(structpb.Value)
+		if err := protojson.Unmarshal(r.Metadata, res.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.Data) > 0 && string(r.Data) != "null" {
+		if err := setResultData(res, r.Data); err != nil {
+			return nil, err
+		}
+	}
+	for i, raw := range r.Trace {
+		step, err := traceStepProto(raw)
+		if err != nil {
+			return nil, fmt.Errorf("trace step %d: %w", i, err)
+		}
+		res.Trace = append(res.Trace, step)
+	}
+	if len(r.Results) > 0 {
+		res.Results = make(map[string]*zdnsv1.Result, len(r.Results))
+		for module, raw := range r.Results {
+			sub, err := ResultProto(raw)
+			if err != nil {
+				return nil, err
+			}
+			res.Results[module] = sub
+		}
+	}
+	return res, nil
+}
+
+// setResultData picks the message for data based on its shape
+func setResultData(res *zdnsv1.Result, data json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := fields[k]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("exchanges"):
+		mx := new(zdnsv1.MXResult)
+		if err := protojsonOptions.Unmarshal(data, mx); err != nil {
+			return err
+		}
+		res.Data = &zdnsv1.Result_Mx{Mx: mx}
+	case has("servers"):
+		ns := new(zdnsv1.NSResult)
+		if err := protojsonOptions.Unmarshal(data, ns); err != nil {
+			return err
+		}
+		res.Data = &zdnsv1.Result_Ns{Ns: ns}
+	case has("answers", "authorities", "additionals", "resolver", "protocol"):
+		dns, err := dnsResultProto(data)
+		if err != nil {
+			return err
+		}
+		res.Data = &zdnsv1.Result_Dns{Dns: dns}
+	case has("ipv4_addresses", "ipv6_addresses"):
+		ip := new(zdnsv1.IPResult)
+		if err := protojsonOptions.Unmarshal(data, ip); err != nil {
+			return err
+		}
+		res.Data = &zdnsv1.Result_Ip{Ip: ip}
+	default:
+		other := new(structpb.Value)
+		if err := protojson.Unmarshal(data, other); err != nil {
+			return err
+		}
+		res.Data = &zdnsv1.Result_Other{Other: other}
+	}
+	return nil
+}
+
+func dnsResultProto(data json.RawMessage) (*zdnsv1.DNSResult, error) {
+	var d struct {
+		textData
+		Flags json.RawMessage `json:"flags"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	res := &zdnsv1.DNSResult{Protocol: d.Protocol, Resolver: d.Resolver}
+	if len(d.Flags) > 0 {
+		res.Flags = new(zdnsv1.DNSFlags)
+		if err := protojsonOptions.Unmarshal(d.Flags, res.Flags); err != nil {
+			return nil, err
+		}
+	}
+	for _, section := range []struct {
+		raw []json.RawMessage
+		out *[]*zdnsv1.Answer
+	}{
+		{d.Answers, &res.Answers},
+		{d.Additional, &res.Additionals},
+		{d.Authorities, &res.Authorities},
+	} {
+		for _, raw := range section.raw {
+			a, err := answerProto(raw)
+			if err != nil {
+				return nil, err
+			}
+			*section.out = append(*section.out, a)
+		}
+	}
+	return res, nil
+}
+
+func traceStepProto(raw json.RawMessage) (*zdnsv1.TraceStep, error) {
+	var t struct {
+		Results    json.RawMessage `json:"results"`
+		Type       uint32          `json:"type"`
+		Class      uint32          `json:"class"`
+		Name       string          `json:"name"`
+		NameServer string          `json:"name_server"`
+		Depth      int32           `json:"depth"`
+		Layer      string          `json:"layer"`
+		Cached     bool            `json:"cached"`
+	}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+	step := &zdnsv1.TraceStep{
+		Type:       t.Type,
+		Class:      t.Class,
+		Name:       t.Name,
+		NameServer: t.NameServer,
+		Depth:      t.Depth,
+		Layer:      t.Layer,
+		Cached:     t.Cached,
+	}
+	if len(t.Results) > 0 {
+		results, err := dnsResultProto(t.Results)
+		if err != nil {
+			return nil, err
+		}
+		step.Results = results
+	}
+	return step, nil
+}
+
+// answerType returns the record type of an answer. CERTAnswer and
+// SSHFPAnswer have a type of their own that hides the record type, so they
+// are told apart by fields no other answer has.
+func answerType(fields map[string]json.RawMessage) (string, error) {
+	switch {
+	case hasFields(fields, "keytag", "certificate"):
+		return "CERT", nil
+	case hasFields(fields, "fingerprint"):
+		return "SSHFP", nil
+	case hasFields(fields, "type"):
+		var rrType string
+		if err := json.Unmarshal(fields["type"], &rrType); err != nil {
+			return "", fmt.Errorf("answer type: %w", err)
+		}
+		return rrType, nil
+	}
+	return "", nil
+}
+
+// answerProto converts an answer to the message of its type, the fields of
+// which are named like those of the answer structs
+func answerProto(raw json.RawMessage) (*zdnsv1.Answer, error) {
+	var hdr struct {
+		Ttl   uint32 `json:"ttl"`
+		Class string `json:"class"`
+		Name  string `json:"name"`
+	}
+	if err := json.Unmarshal(raw, &hdr); err != nil {
+		return nil, err
+	}
+	rrType, err := answerType(rawFields(raw))
+	if err != nil {
+		return nil, err
+	}
+	a := &zdnsv1.Answer{Name: hdr.Name, Class: hdr.Class, Ttl: hdr.Ttl, Type: rrType}
+	unmarshal := func(m proto.Message) error {
+		return protojsonOptions.Unmarshal(raw, m)
+	}
+	switch a.Type {
+	case "MX", "KX", "RT", "NID":
+		m := new(zdnsv1.PrefAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Pref{Pref: m}
+	case "SOA":
+		m := new(zdnsv1.SOAAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Soa{Soa: m}
+	case "SRV":
+		m := new(zdnsv1.SRVAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Srv{Srv: m}
+	case "SVCB", "HTTPS":
+		m := new(zdnsv1.SVCBAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Svcb{Svcb: m}
+	case "DNSKEY", "CDNSKEY":
+		m := new(zdnsv1.DNSKEYAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Dnskey{Dnskey: m}
+	case "DS", "CDS":
+		m := new(zdnsv1.DSAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Ds{Ds: m}
+	case "RRSIG", "SIG":
+		m := new(zdnsv1.RRSIGAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Rrsig{Rrsig: m}
+	case "CAA":
+		m := new(zdnsv1.CAAAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Caa{Caa: m}
+	case "TLSA":
+		m := new(zdnsv1.TLSAAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Tlsa{Tlsa: m}
+	case "SMIMEA":
+		m := new(zdnsv1.SMIMEAAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Smimea{Smimea: m}
+	case "NSEC":
+		m := new(zdnsv1.NSECAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Nsec{Nsec: m}
+	case "NSEC3", "NSEC3PARAM":
+		m := new(zdnsv1.NSEC3Answer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Nsec3{Nsec3: m}
+	case "NAPTR":
+		m := new(zdnsv1.NAPTRAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Naptr{Naptr: m}
+	case "SSHFP":
+		m := new(zdnsv1.SSHFPAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Sshfp{Sshfp: m}
+	case "URI":
+		m := new(zdnsv1.URIAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Uri{Uri: m}
+	case "AFSDB":
+		m := new(zdnsv1.AFSDBAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Afsdb{Afsdb: m}
+	case "CERT":
+		m := new(zdnsv1.CERTAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Cert{Cert: m}
+	case "GPOS":
+		m := new(zdnsv1.GPOSAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Gpos{Gpos: m}
+	case "HINFO":
+		m := new(zdnsv1.HINFOAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Hinfo{Hinfo: m}
+	case "HIP":
+		m := new(zdnsv1.HIPAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Hip{Hip: m}
+	case "LOC":
+		m := new(zdnsv1.LOCAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Loc{Loc: m}
+	case "MINFO":
+		m := new(zdnsv1.MINFOAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Minfo{Minfo: m}
+	case "PX":
+		m := new(zdnsv1.PXAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Px{Px: m}
+	case "RP":
+		m := new(zdnsv1.RPAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Rp{Rp: m}
+	case "TALINK":
+		m := new(zdnsv1.TALINKAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Talink{Talink: m}
+	case "TKEY":
+		m := new(zdnsv1.TKEYAnswer)
+		err = unmarshal(m)
+		a.Data = &zdnsv1.Answer_Tkey{Tkey: m}
+	default:
+		var base Answer
+		err = json.Unmarshal(raw, &base)
+		a.Data = &zdnsv1.Answer_Answer{Answer: base.Answer}
+	}
+	return a, err
+}
@@ -0,0 +1,122 @@
+package miekg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/zmap/dns"
+	zdnsv1 "github.com/zmap/zdns/pkg/zdns/zdnspb/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+)
+
+func TestProtobufEncoder(t *testing.T) {
+	var b bytes.Buffer
+	enc := &protobufEncoder{w: &b}
+	for _, res := range []string{
+		`{"name":"example.com","status":"NOERROR","metadata":{"id":1},"data":{"answers":[{"ttl":60,"type":"MX","class":"IN","name":"example.com","answer":"mail.example.com.","preference":10},{"ttl":60,"type":"SOA","class":"IN","name":"example.com","ns":"ns.example.com.","mbox":"admin.example.com.","serial":7,"refresh":3600,"retry":600,"expire":86400,"min_ttl":300},{"ttl":60,"type":"A","class":"IN","name":"example.com","answer":"192.0.2.1"}],"protocol":"udp","resolver":"192.0.2.53:53","flags":{"response":true,"error_code":0}}}`,
+		`{"name":"example.net","status":"NXDOMAIN"}`,
+		`{"name":"example.org","results":{"MXLOOKUP":{"status":"NOERROR","data":{"exchanges":[{"name":"mx.example.org","type":"MX","preference":5,"ipv4_addresses":["192.0.2.1"]}]}},"SPF":{"status":"NOERROR","data":{"spf":"v=spf1 -all"}}}}`,
+	} {
+		assert.NilError(t, enc.Encode(res))
+	}
+	assert.NilError(t, enc.Close())
+
+	var results []*zdnsv1.Result
+	r := bufio.NewReader(&b)
+	for {
+		n, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		msg := make([]byte, n)
+		_, err = io.ReadFull(r, msg)
+		assert.NilError(t, err)
+		res := new(zdnsv1.Result)
+		assert.NilError(t, proto.Unmarshal(msg, res))
+		results = append(results, res)
+	}
+	assert.Equal(t, len(results), 3)
+
+	assert.Equal(t, results[0].Name, "example.com")
+	assert.Equal(t, results[0].Metadata.GetStructValue().Fields["id"].GetNumberValue(), 1.0)
+	dns := results[0].GetDns()
+	assert.Equal(t, dns.Resolver, "192.0.2.53:53")
+	assert.Assert(t, dns.Flags.Response)
+	assert.Equal(t, len(dns.Answers), 3)
+	assert.Equal(t, dns.Answers[0].Type, "MX")
+	assert.Equal(t, dns.Answers[0].Ttl, uint32(60))
+	assert.Equal(t, dns.Answers[0].GetPref().Answer, "mail.example.com.")
+	assert.Equal(t, dns.Answers[0].GetPref().Preference, uint32(10))
+	assert.Equal(t, dns.Answers[1].GetSoa().Serial, uint32(7))
+	assert.Equal(t, dns.Answers[1].GetSoa().MinTtl, uint32(300))
+	assert.Equal(t, dns.Answers[2].GetAnswer(), "192.0.2.1")
+
+	assert.Equal(t, results[1].Status, "NXDOMAIN")
+	assert.Assert(t, results[1].Data == nil)
+
+	mx := results[2].Results["MXLOOKUP"].GetMx()
+	assert.Equal(t, mx.Exchanges[0].Name, "mx.example.org")
+	assert.Equal(t, mx.Exchanges[0].Preference, uint32(5))
+	assert.DeepEqual(t, mx.Exchanges[0].Ipv4Addresses, []string{"192.0.2.1"})
+	spf := results[2].Results["SPF"].GetOther().GetStructValue()
+	assert.Equal(t, spf.Fields["spf"].GetStringValue(), "v=spf1 -all")
+}
+
+func TestResultProtoTrace(t *testing.T) {
+	res, err := ResultProto([]byte(`{"name":"example.com","status":"NOERROR","trace":[{"results":{"answers":[{"ttl":60,"type":"A","class":"IN","name":"example.com","answer":"192.0.2.1"}],"protocol":"udp","resolver":"192.0.2.53:53"},"type":1,"class":1,"name":"example.com","name_server":"192.0.2.53:53","depth":1,"layer":".","cached":false}]}`))
+	assert.NilError(t, err)
+	assert.Equal(t, len(res.Trace), 1)
+	assert.Equal(t, res.Trace[0].NameServer, "192.0.2.53:53")
+	assert.Equal(t, res.Trace[0].Results.Answers[0].GetAnswer(), "192.0.2.1")
+
+	// a step that can't be converted isn't dropped from the trace
+	_, err = ResultProto([]byte(`{"name":"example.com","status":"NOERROR","trace":[{"type":1,"name":"example.com"},{"type":"A","name":"example.com"}]}`))
+	assert.ErrorContains(t, err, "trace step 1")
+}
+
+func TestResultProtoCERTAndSSHFP(t *testing.T) {
+	var answers []interface{}
+	for _, s := range []string{
+		"example.com. 60 IN CERT URI 12 RSASHA256 AQID",
+		"example.com. 60 IN SSHFP 4 2 0123456789abcdef",
+	} {
+		rr, err := dns.NewRR(s)
+		assert.NilError(t, err)
+		answers = append(answers, ParseAnswer(rr))
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"name":   "example.com",
+		"status": "NOERROR",
+		"data":   map[string]interface{}{"answers": answers},
+	})
+	assert.NilError(t, err)
+	res, err := ResultProto(data)
+	assert.NilError(t, err)
+	got := res.GetDns().Answers
+	assert.Equal(t, len(got), 2)
+
+	// the certificate type URI isn't taken for the record type
+	assert.Equal(t, got[0].Type, "CERT")
+	assert.Equal(t, got[0].Name, "example.com")
+	cert := got[0].GetCert()
+	assert.Equal(t, cert.Type, "URI")
+	assert.Equal(t, cert.Keytag, uint32(12))
+	assert.Equal(t, cert.Algorithm, "RSASHA256")
+	assert.Equal(t, cert.Certificate, "AQID")
+
+	assert.Equal(t, got[1].Type, "SSHFP")
+	sshfp := got[1].GetSshfp()
+	assert.Equal(t, sshfp.Algorithm, uint32(4))
+	assert.Equal(t, sshfp.Type, uint32(2))
+	assert.Equal(t, sshfp.Fingerprint, "0123456789abcdef")
+
+	// a type that isn't a string is an error rather than a guess
+	_, err = ResultProto([]byte(`{"name":"example.com","status":"NOERROR","data":{"answers":[{"ttl":60,"type":1,"name":"example.com"}]}}`))
+	assert.ErrorContains(t, err, "answer type")
+}
@@ -0,0 +1,394 @@
+package miekg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/iohandlers"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+func init() {
+	zdns.RegisterOutputFormat("text", zdns.OutputFormat{New: func(gc *zdns.GlobalConf) (iohandlers.EncoderFactory, error) {
+		module := gc.Module
+		if gc.ModulePerLine || gc.InputFormat == "jsonl" {
+			// the query type of each line is unknown
+			module = ""
+		}
+		class := dns.Class(gc.Class).String()
+		return func(w io.Writer) (iohandlers.Encoder, error) {
+			return &textEncoder{w: w, module: module, class: class}, nil
+		}, nil
+	}})
+}
+
+// textEncoder writes results the way dig does, for reading at a terminal
+type textEncoder struct {
+	w      io.Writer
+	module string
+	class  string
+}
+
+// textResult holds the fields of a JSON result that are written as text
+type textResult struct {
+	AlteredName string                 `json:"altered_name"`
+	Name        string                 `json:"name"`
+	Class       string                 `json:"class"`
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	Timestamp   string                 `json:"timestamp"`
+	Data        json.RawMessage        `json:"data"`
+	Results     map[string]*textResult `json:"results"`
+}
+
+// textData is the JSON form of Result, keeping the answers as JSON until
+// their type is known
+type textData struct {
+	Answers     []json.RawMessage `json:"answers"`
+	Additional  []json.RawMessage `json:"additionals"`
+	Authorities []json.RawMessage `json:"authorities"`
+	Protocol    string            `json:"protocol"`
+	Resolver    string            `json:"resolver"`
+	Flags       *DNSFlags         `json:"flags"`
+}
+
+func (e *textEncoder) Encode(result string) error {
+	var res textResult
+	if err := json.Unmarshal([]byte(result), &res); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if len(res.Results) > 0 {
+		modules := make([]string, 0, len(res.Results))
+		for m := range res.Results {
+			modules = append(modules, m)
+		}
+		sort.Strings(modules)
+		for _, m := range modules {
+			sub := res.Results[m]
+			sub.Name, sub.AlteredName = res.Name, res.AlteredName
+			e.writeResult(&b, sub, m)
+		}
+	} else {
+		e.writeResult(&b, &res, e.module)
+	}
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *textEncoder) Close() error {
+	return nil
+}
+
+func (e *textEncoder) writeResult(b *strings.Builder, res *textResult, module string) {
+	name := res.Name
+	if res.AlteredName != "" {
+		name = res.AlteredName
+	}
+	class := res.Class
+	if class == "" {
+		class = e.class
+	}
+	fmt.Fprintf(b, "; <<>> zdns <<>> %s\n", strings.TrimSpace(module+" "+name))
+	var data textData
+	raw := len(res.Data) > 0 && json.Unmarshal(res.Data, &data) == nil && (data.Answers != nil || data.Authorities != nil ||
+		data.Additional != nil || data.Resolver != "" || data.Protocol != "")
+	fmt.Fprintf(b, ";; ->>HEADER<<- status: %s\n", res.Status)
+	if res.Error != "" {
+		fmt.Fprintf(b, ";; ERROR: %s\n", res.Error)
+	}
+	if raw {
+		if data.Flags != nil {
+			fmt.Fprintf(b, ";; flags:%s; ", flagString(data.Flags))
+		} else {
+			b.WriteString(";; ")
+		}
+		fmt.Fprintf(b, "ANSWER: %d, AUTHORITY: %d, ADDITIONAL: %d\n", len(data.Answers), len(data.Authorities), len(data.Additional))
+	}
+	if _, ok := dns.StringToType[module]; ok {
+		fmt.Fprintf(b, "\n;; QUESTION SECTION:\n;%s\t\t%s\t%s\n", dns.Fqdn(name), class, module)
+	}
+	if raw {
+		writeSection(b, "ANSWER", data.Answers)
+		writeSection(b, "AUTHORITY", data.Authorities)
+		writeSection(b, "ADDITIONAL", data.Additional)
+		if data.Resolver != "" {
+			fmt.Fprintf(b, "\n;; SERVER: %s (%s)\n", data.Resolver, data.Protocol)
+		}
+	} else if len(res.Data) > 0 {
+		// modules like MXLOOKUP don't return DNS messages
+		var v interface{}
+		json.Unmarshal(res.Data, &v)
+		j, _ := json.MarshalIndent(v, ";; ", "  ")
+		fmt.Fprintf(b, "\n;; DATA:\n;; %s\n", j)
+	}
+	if res.Timestamp != "" {
+		fmt.Fprintf(b, ";; WHEN: %s\n", res.Timestamp)
+	}
+	b.WriteString("\n")
+}
+
+func flagString(f *DNSFlags) string {
+	var s string
+	for _, flag := range []struct {
+		set  bool
+		name string
+	}{
+		{f.Response, "qr"},
+		{f.Authoritative, "aa"},
+		{f.Truncated, "tc"},
+		{f.RecursionDesired, "rd"},
+		{f.RecursionAvailable, "ra"},
+		{f.Authenticated, "ad"},
+		{f.CheckingDisabled, "cd"},
+	} {
+		if flag.set {
+			s += " " + flag.name
+		}
+	}
+	return s
+}
+
+func writeSection(b *strings.Builder, name string, answers []json.RawMessage) {
+	if len(answers) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n;; %s SECTION:\n", name)
+	for _, a := range answers {
+		b.WriteString(presentation(a))
+		b.WriteString("\n")
+	}
+}
+
+// presentation formats an answer the way it would appear in a zone file.
+// Answers whose JSON form doesn't hold all of their data (e.g., types that
+// ParseAnswer doesn't model, or NSEC3 records, which lack the next hashed
+// owner and type bitmap) are written as a comment rather than as a partial
+// record.
+func presentation(raw json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	var hdr Answer
+	if json.Unmarshal(raw, &fields) != nil || !hasFields(fields, "name", "ttl", "class", "type") || json.Unmarshal(raw, &hdr) != nil {
+		return "; " + string(raw)
+	}
+	// the type fields of CERT and SSHFP answers hide the RR type
+	if _, ok := dns.StringToType[hdr.Type]; !ok {
+		return "; " + string(raw)
+	}
+	if _, ok := dns.StringToClass[hdr.Class]; !ok {
+		return "; " + string(raw)
+	}
+	rdata, ok := rdataPresentation(hdr.Type, raw, fields)
+	if !ok {
+		return "; " + string(raw)
+	}
+	return fmt.Sprintf("%s\t%d\t%s\t%s\t%s", dns.Fqdn(hdr.Name), hdr.Ttl, hdr.Class, hdr.Type, rdata)
+}
+
+// hasFields reports whether all of keys are in fields
+func hasFields(fields map[string]json.RawMessage, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := fields[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rdataPresentation formats the data of an answer of type rrType, using the
+// answer struct that ParseAnswer returns for the type. It fails unless the
+// answer has all the fields the data is made of, so that missing fields
+// aren't written as zero values.
+func rdataPresentation(rrType string, raw json.RawMessage, fields map[string]json.RawMessage) (string, bool) {
+	decode := func(v interface{}, keys ...string) bool {
+		return hasFields(fields, keys...) && json.Unmarshal(raw, v) == nil
+	}
+	switch rrType {
+	case "NS", "CNAME", "DNAME", "PTR", "MB", "MG", "MF", "MD", "NSAPPTR":
+		var a Answer
+		ok := decode(&a, "answer")
+		return fqdn(a.Answer), ok
+	case "TXT", "SPF", "AVC", "NINFO":
+		var a Answer
+		ok := decode(&a, "answer")
+		if rrType == "SPF" {
+			// the SPF answer is the whole record as printed by the dns package
+			if i := strings.Index(a.Answer, "\tSPF\t"); i >= 0 {
+				return a.Answer[i+len("\tSPF\t"):], ok
+			}
+			return "", false
+		}
+		var parts []string
+		for _, s := range strings.Split(a.Answer, "\n") {
+			parts = append(parts, quote(s))
+		}
+		return strings.Join(parts, " "), ok
+	case "MX", "RT", "KX", "LP":
+		var a PrefAnswer
+		ok := decode(&a, "answer", "preference")
+		return fmt.Sprintf("%d %s", a.Preference, fqdn(a.Answer.Answer)), ok
+	case "L32":
+		var a PrefAnswer
+		ok := decode(&a, "answer", "preference")
+		return fmt.Sprintf("%d %s", a.Preference, a.Answer.Answer), ok && a.Answer.Answer != ""
+	case "NID", "L64":
+		var a PrefAnswer
+		ok := decode(&a, "answer", "preference")
+		node := a.Answer.Answer
+		if len(node) != 16 {
+			return "", false
+		}
+		return fmt.Sprintf("%d %s:%s:%s:%s", a.Preference, node[0:4], node[4:8], node[8:12], node[12:16]), ok
+	case "SOA":
+		var a SOAAnswer
+		ok := decode(&a, "ns", "mbox", "serial", "refresh", "retry", "expire", "min_ttl")
+		return fmt.Sprintf("%s %s %d %d %d %d %d", fqdn(a.Ns), fqdn(a.Mbox), a.Serial, a.Refresh, a.Retry, a.Expire, a.Minttl), ok
+	case "CAA":
+		var a CAAAnswer
+		ok := decode(&a, "flag", "tag", "value")
+		return fmt.Sprintf("%d %s %s", a.Flag, a.Tag, quote(a.Value)), ok
+	case "SRV":
+		var a SRVAnswer
+		ok := decode(&a, "priority", "weight", "port", "target")
+		return fmt.Sprintf("%d %d %d %s", a.Priority, a.Weight, a.Port, fqdn(a.Target)), ok
+	case "DS", "CDS":
+		var a DSAnswer
+		ok := decode(&a, "key_tag", "algorithm", "digest_type", "digest")
+		return fmt.Sprintf("%d %d %d %s", a.KeyTag, a.Algorithm, a.DigestType, strings.ToUpper(a.Digest)), ok
+	case "DNSKEY", "CDNSKEY":
+		var a DNSKEYAnswer
+		ok := decode(&a, "flags", "protocol", "algorithm", "public_key")
+		return fmt.Sprintf("%d %d %d %s", a.Flags, a.Protocol, a.Algorithm, a.PublicKey), ok
+	case "RRSIG", "SIG":
+		var a RRSIGAnswer
+		ok := decode(&a, "type_covered", "algorithm", "labels", "original_ttl", "expiration", "inception", "keytag", "signer_name", "signature")
+		return fmt.Sprintf("%s %d %d %d %s %s %d %s %s", dns.Type(a.TypeCovered), a.Algorithm, a.Labels, a.OriginalTtl,
+			a.Expiration, a.Inception, a.KeyTag, fqdn(a.SignerName), a.Signature), ok
+	case "TLSA":
+		var a TLSAAnswer
+		ok := decode(&a, "cert_usage", "selector", "matching_type", "certificate")
+		return fmt.Sprintf("%d %d %d %s", a.CertUsage, a.Selector, a.MatchingType, a.Certificate), ok
+	case "SMIMEA":
+		var a SMIMEAAnswer
+		ok := decode(&a, "usage", "selector", "matching_type", "certificate")
+		return fmt.Sprintf("%d %d %d %s", a.Usage, a.Selector, a.MatchingType, a.Certificate), ok
+	case "NSEC3PARAM":
+		var a NSEC3ParamAnswer
+		ok := decode(&a, "hash_algorithm", "flags", "iterations", "salt")
+		return fmt.Sprintf("%d %d %d %s", a.HashAlgorithm, a.Flags, a.Iterations, emptyDash(strings.ToUpper(a.Salt))), ok
+	case "NAPTR":
+		var a NAPTRAnswer
+		ok := decode(&a, "order", "preference", "flags", "service", "regexp", "replacement")
+		return fmt.Sprintf("%d %d %s %s %s %s", a.Order, a.Preference, quote(a.Flags), quote(a.Service), quote(a.Regexp), fqdn(a.Replacement)), ok
+	case "HINFO":
+		var a HINFOAnswer
+		ok := decode(&a, "cpu", "os")
+		return fmt.Sprintf("%s %s", quote(a.Cpu), quote(a.Os)), ok
+	case "MINFO":
+		var a MINFOAnswer
+		ok := decode(&a, "rmail", "email")
+		return fmt.Sprintf("%s %s", fqdn(a.Rmail), fqdn(a.Email)), ok
+	case "AFSDB":
+		var a AFSDBAnswer
+		ok := decode(&a, "subtype", "hostname")
+		return fmt.Sprintf("%d %s", a.Subtype, fqdn(a.Hostname)), ok
+	case "PX":
+		var a PXAnswer
+		ok := decode(&a, "preference", "map822", "mapx400")
+		return fmt.Sprintf("%d %s %s", a.Preference, fqdn(a.Map822), fqdn(a.Mapx400)), ok
+	case "URI":
+		var a URIAnswer
+		ok := decode(&a, "priority", "weight", "target")
+		return fmt.Sprintf("%d %d %s", a.Priority, a.Weight, quote(a.Target)), ok
+	case "RP":
+		var a RPAnswer
+		ok := decode(&a, "mbox", "txt")
+		return fmt.Sprintf("%s %s", fqdn(a.Mbox), fqdn(a.Txt)), ok
+	case "TALINK":
+		var a TALINKAnswer
+		ok := decode(&a, "previous_name", "next_name")
+		return fmt.Sprintf("%s %s", fqdn(a.PreviousName), fqdn(a.NextName)), ok
+	case "SVCB", "HTTPS":
+		var a SVCBAnswer
+		ok := decode(&a, "priority", "target")
+		s := fmt.Sprintf("%d %s", a.Priority, fqdn(a.Target))
+		written := 0
+		for _, k := range svcParamKeys {
+			if v, found := a.SVCParams[k]; found {
+				p, pOK := svcParam(k, v)
+				s += " " + p
+				ok = ok && pOK
+				written++
+			}
+		}
+		return s, ok && written == len(a.SVCParams)
+	case "A", "AAAA", "EUI48", "EUI64", "UID", "GID", "OPENPGPKEY", "DHCID", "EID", "NIMLOC":
+		// the answer is the data as the dns package prints it
+		var a Answer
+		if !decode(&a, "answer") || a.Answer == "" {
+			return "", false
+		}
+		return a.Answer, true
+	}
+	return "", false
+}
+
+// svcParamKeys are the SvcParamKeys that svcParam can write, in the order of
+// their numbers
+var svcParamKeys = []string{"mandatory", "alpn", "no-default-alpn", "port", "ipv4hint", "echconfig", "ipv6hint"}
+
+// svcParam formats a SvcParam of an SVCB answer. The values of other keys
+// (e.g., keyNNNNN) are bytes in JSON, which can't be written back as they
+// were.
+func svcParam(key string, v interface{}) (string, bool) {
+	switch key {
+	case "no-default-alpn":
+		return key + `=""`, v == true
+	case "port":
+		if n, ok := v.(float64); ok {
+			return fmt.Sprintf("%s=\"%d\"", key, int(n)), true
+		}
+	case "echconfig":
+		if s, ok := v.(string); ok {
+			return fmt.Sprintf("%s=\"%s\"", key, s), true
+		}
+	case "mandatory", "alpn", "ipv4hint", "ipv6hint":
+		list, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		parts := make([]string, len(list))
+		for i, p := range list {
+			s, ok := p.(string)
+			// commas and quotes would have to be escaped
+			if !ok || strings.ContainsAny(s, ",\"\\") {
+				return "", false
+			}
+			parts[i] = s
+		}
+		return fmt.Sprintf("%s=\"%s\"", key, strings.Join(parts, ",")), true
+	}
+	return "", false
+}
+
+func fqdn(name string) string {
+	if name == "" {
+		return "."
+	}
+	return dns.Fqdn(name)
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func emptyDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
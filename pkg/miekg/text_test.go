@@ -0,0 +1,110 @@
+package miekg
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zmap/dns"
+	"gotest.tools/v3/assert"
+)
+
+func rrJSON(t *testing.T, s string) json.RawMessage {
+	rr, err := dns.NewRR(s)
+	assert.NilError(t, err)
+	b, err := json.Marshal(ParseAnswer(rr))
+	assert.NilError(t, err)
+	return b
+}
+
+func TestPresentation(t *testing.T) {
+	for _, rr := range []string{
+		"example.com.\t300\tIN\tA\t192.0.2.1",
+		"example.com.\t300\tIN\tMX\t10 mail.example.com.",
+		"example.com.\t300\tIN\tSOA\tns1.example.com. hostmaster.example.com. 2022010101 7200 3600 1209600 300",
+		"example.com.\t300\tIN\tCAA\t0 issue \"letsencrypt.org\"",
+		"_sip._tcp.example.com.\t300\tIN\tSRV\t10 60 5060 sip.example.com.",
+		"example.com.\t300\tIN\tTXT\t\"v=spf1 -all\"",
+		"example.com.\t300\tIN\tDS\t60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118",
+		"_443._tcp.example.com.\t300\tIN\tTLSA\t3 1 1 0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6",
+		"www.example.com.\t300\tIN\tCNAME\texample.com.",
+	} {
+		assert.Equal(t, presentation(rrJSON(t, rr)), rr)
+	}
+}
+
+// TestPresentationRoundTrip checks that records are either written as the
+// dns package writes them, or as a comment, but never partially
+func TestPresentationRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"example.com. 300 IN AAAA 2001:db8::1",
+		"example.com. 300 IN TXT \"a b\" \"c\"",
+		"example.com. 300 IN SPF \"v=spf1 -all\"",
+		"example.com. 300 IN L32 10 10.1.2.0",
+		"example.com. 300 IN L64 10 2001:0DB8:1140:1000",
+		"example.com. 300 IN LP 10 l64-subnet.example.com.",
+		"example.com. 300 IN NID 10 0014:4fff:ff20:ee64",
+		"example.com. 300 IN NSEC3PARAM 1 0 12 aabbccdd",
+		"example.com. 300 IN URI 10 1 \"ftp://ftp1.example.com/public\"",
+		"example.com. 300 IN EUI48 00-00-5e-00-53-2a",
+		"example.com. 300 IN HINFO \"cpu\" \"os\"",
+		"example.com. 300 IN NAPTR 100 10 \"u\" \"sip+E2U\" \"!^.*$!sip:info@example.com!\" .",
+		"example.com. 300 IN DNSKEY 257 3 8 AwEAAag=",
+		"example.com. 300 IN RRSIG A 8 2 300 20220101000000 20211201000000 12345 example.com. c2ln",
+		"example.com. 300 IN SVCB 1 svc.example.com. mandatory=alpn alpn=h2,h3 no-default-alpn port=8443 ipv4hint=192.0.2.1,192.0.2.2 echconfig=AAAA ipv6hint=2001:db8::1",
+		"example.com. 300 IN HTTPS 1 . alpn=h2",
+		"example.com. 300 IN OPENPGPKEY AwEAAag=",
+	} {
+		rr, err := dns.NewRR(s)
+		assert.NilError(t, err)
+		assert.Equal(t, presentation(rrJSON(t, s)), rr.String())
+	}
+	// the dns package writes IPv4-mapped addresses as IPv4 addresses
+	assert.Equal(t, presentation(rrJSON(t, "example.com. 300 IN AAAA ::ffff:192.0.2.1")), "example.com.\t300\tIN\tAAAA\t::ffff:192.0.2.1")
+	// the JSON of these doesn't hold all of their data
+	for _, s := range []string{
+		"example.com. 300 IN LOC 52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m",
+		"example.com. 300 IN GPOS -32.6882 116.8652 10.0",
+		"example.com. 300 IN HIP 2 200100107B1A74DF365639CC39F1D578 AwEAAbdxyhNuSutc5EMzxTs9LBPCIkOFH8cIvM4p9+LrV4e19WzK00+CI6zBCQTdtWsuxKbWIy87UOoJTwkUs7lBu+Upr1gsNrut79ryra+bSRGQb1slImA8YVJyuIDsj7kwzG7jnERNqnWxZ48AWkskmdHaVDP4BcelrTI3rMXdXF5D rvs.example.com.",
+		"example.com. 300 IN CSYNC 66 3 A NS AAAA",
+		"example.com. 300 IN CERT PKIX 1 RSASHA256 MxFcby9k/yvedMfQgKzhH5er0Mu/vILz45IkskceFGgiWCn/GxHhai6VAuHAoNUz4YoU1tVfSCSqQYn6//11UA==",
+		"example.com. 300 IN SSHFP 1 1 123456789ABCDEF67890123456789ABCDEF67890",
+		"example.com. 300 IN NSEC3 1 1 12 aabbccdd 2vptu5timamqttgl4luu9kg21e0aor3s A RRSIG",
+		"example.com. 300 IN NSEC next.example.com. A NS RRSIG",
+		"example.com. 300 IN SVCB 1 svc.example.com. key65000=foo",
+	} {
+		out := presentation(rrJSON(t, s))
+		assert.Assert(t, strings.HasPrefix(out, "; "), out)
+		assert.Assert(t, !strings.Contains(out, "\n"), out)
+	}
+	// zero values are not data
+	for _, raw := range []string{
+		`{"type":"URI","class":"IN"}`,
+		`{"ttl":300,"type":"URI","class":"IN","name":"example.com","priority":10}`,
+		`{"ttl":300,"type":"MX","class":"IN","name":"example.com","answer":"mail.example.com"}`,
+		`{"ttl":300,"type":"A","class":"IN","name":"example.com"}`,
+	} {
+		assert.Equal(t, presentation(json.RawMessage(raw)), "; "+raw)
+	}
+}
+
+func TestTextEncoder(t *testing.T) {
+	var b bytes.Buffer
+	e := &textEncoder{w: &b, module: "A", class: "IN"}
+	assert.NilError(t, e.Encode(`{"name":"example.com","status":"NOERROR","timestamp":"t","data":{"answers":[{"ttl":60,"type":"A","class":"IN","name":"example.com","answer":"192.0.2.1"}],"protocol":"udp","resolver":"192.0.2.53:53","flags":{"response":true,"recursion_desired":true,"recursion_available":true}}}`))
+	assert.Equal(t, b.String(), `; <<>> zdns <<>> A example.com
+;; ->>HEADER<<- status: NOERROR
+;; flags: qr rd ra; ANSWER: 1, AUTHORITY: 0, ADDITIONAL: 0
+
+;; QUESTION SECTION:
+;example.com.		IN	A
+
+;; ANSWER SECTION:
+example.com.	60	IN	A	192.0.2.1
+
+;; SERVER: 192.0.2.53:53 (udp)
+;; WHEN: t
+
+`)
+}
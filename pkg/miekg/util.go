@@ -102,6 +102,24 @@ func SafeStatus(status zdns.Status) bool {
 	return status == zdns.STATUS_NOERROR
 }
 
+// LookupFailed is whether status is a failed lookup, rather than one that
+// found nothing
+func LookupFailed(status zdns.Status) bool {
+	switch status {
+	case zdns.STATUS_NOERROR, zdns.STATUS_NXDOMAIN, zdns.STATUS_NO_ANSWER, zdns.STATUS_NO_RECORD:
+		return false
+	}
+	return true
+}
+
+// LookupError describes a failed lookup for the errors of a result
+func LookupError(status zdns.Status, err error) string {
+	if err != nil {
+		return string(status) + ": " + err.Error()
+	}
+	return string(status)
+}
+
 // Verify that A record is indeed IPv4 and AAAA is IPv6
 func VerifyAddress(ansType string, ip string) bool {
 	isIpv4 := false
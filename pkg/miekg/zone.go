@@ -0,0 +1,121 @@
+package miekg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/iohandlers"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+func init() {
+	zdns.RegisterOutputFormat("zone", zdns.OutputFormat{New: func(gc *zdns.GlobalConf) (iohandlers.EncoderFactory, error) {
+		module := gc.Module
+		if gc.ModulePerLine || gc.InputFormat == "jsonl" {
+			module = ""
+		}
+		return func(w io.Writer) (iohandlers.Encoder, error) {
+			return &zoneEncoder{w: w, module: module}, nil
+		}, nil
+	}})
+}
+
+// zoneEncoder writes the records of results in RFC 1035 master file format,
+// with everything else (e.g., the status of each lookup) in comments, so that
+// the output can be loaded as a zone
+type zoneEncoder struct {
+	w      io.Writer
+	module string
+}
+
+// zoneData holds the parts of the data of a result that contain records: the
+// sections of raw lookups, and the transfers of AXFR
+type zoneData struct {
+	Answers     []json.RawMessage `json:"answers"`
+	Additional  []json.RawMessage `json:"additionals"`
+	Authorities []json.RawMessage `json:"authorities"`
+	Servers     []struct {
+		Server  string            `json:"server"`
+		Status  string            `json:"status"`
+		Error   string            `json:"error"`
+		Records []json.RawMessage `json:"records"`
+	} `json:"servers"`
+}
+
+func (e *zoneEncoder) Encode(result string) error {
+	var res textResult
+	if err := json.Unmarshal([]byte(result), &res); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if len(res.Results) > 0 {
+		modules := make([]string, 0, len(res.Results))
+		for m := range res.Results {
+			modules = append(modules, m)
+		}
+		sort.Strings(modules)
+		for _, m := range modules {
+			sub := res.Results[m]
+			sub.Name, sub.AlteredName = res.Name, res.AlteredName
+			writeZoneResult(&b, sub, m)
+		}
+	} else {
+		writeZoneResult(&b, &res, e.module)
+	}
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *zoneEncoder) Close() error {
+	return nil
+}
+
+func writeZoneResult(b *strings.Builder, res *textResult, module string) {
+	name := res.Name
+	if res.AlteredName != "" {
+		name = res.AlteredName
+	}
+	fmt.Fprintf(b, "; %s\n", strings.Join(nonEmpty(dns.Fqdn(name), module, res.Status, res.Error), " "))
+	var data zoneData
+	if len(res.Data) == 0 || json.Unmarshal(res.Data, &data) != nil {
+		return
+	}
+	writeRecords(b, data.Answers)
+	if len(data.Authorities) > 0 {
+		b.WriteString("; authority\n")
+		writeRecords(b, data.Authorities)
+	}
+	if len(data.Additional) > 0 {
+		b.WriteString("; additional\n")
+		writeRecords(b, data.Additional)
+	}
+	for _, s := range data.Servers {
+		if s.Status == "" {
+			// the servers of NSLOOKUP
+			continue
+		}
+		fmt.Fprintf(b, "; transfer from %s\n", strings.Join(nonEmpty(s.Server, s.Status, s.Error), " "))
+		writeRecords(b, s.Records)
+	}
+}
+
+func writeRecords(b *strings.Builder, records []json.RawMessage) {
+	for _, r := range records {
+		b.WriteString(presentation(r))
+		b.WriteString("\n")
+	}
+}
+
+func nonEmpty(s ...string) []string {
+	var out []string
+	for _, x := range s {
+		if x != "" {
+			out = append(out, x)
+		}
+	}
+	return out
+}
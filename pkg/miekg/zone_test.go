@@ -0,0 +1,61 @@
+package miekg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestZoneEncoder(t *testing.T) {
+	var b bytes.Buffer
+	e := &zoneEncoder{w: &b, module: "MX"}
+	assert.NilError(t, e.Encode(`{"name":"example.com","status":"NOERROR","data":{"answers":[{"ttl":60,"type":"MX","class":"IN","name":"example.com","answer":"mail.example.com.","preference":10}],"additionals":[{"ttl":60,"type":"A","class":"IN","name":"mail.example.com","answer":"192.0.2.1"}],"protocol":"udp","resolver":"192.0.2.53:53"}}`))
+	assert.NilError(t, e.Encode(`{"name":"example.net","status":"NXDOMAIN"}`))
+	assert.Equal(t, b.String(), `; example.com. MX NOERROR
+example.com.	60	IN	MX	10 mail.example.com.
+; additional
+mail.example.com.	60	IN	A	192.0.2.1
+; example.net. MX NXDOMAIN
+`)
+}
+
+func TestZoneEncoderAXFR(t *testing.T) {
+	var b bytes.Buffer
+	e := &zoneEncoder{w: &b, module: "AXFR"}
+	assert.NilError(t, e.Encode(`{"name":"example.com","status":"NOERROR","data":{"servers":[{"server":"192.0.2.53","status":"NOERROR","records":[{"ttl":3600,"type":"SOA","class":"IN","name":"example.com","ns":"ns.example.com","mbox":"admin.example.com","serial":1,"refresh":7200,"retry":600,"expire":86400,"min_ttl":300},{"ttl":3600,"type":"A","class":"IN","name":"www.example.com","answer":"192.0.2.1"}]},{"server":"192.0.2.54","status":"ERROR","error":"dns: bad xfr rcode: 5"}]}}`))
+	assert.Equal(t, b.String(), `; example.com. AXFR NOERROR
+; transfer from 192.0.2.53 NOERROR
+example.com.	3600	IN	SOA	ns.example.com. admin.example.com. 1 7200 600 86400 300
+www.example.com.	3600	IN	A	192.0.2.1
+; transfer from 192.0.2.54 ERROR dns: bad xfr rcode: 5
+`)
+}
+
+// TestZoneEncoderLossyRecords checks that records that can't be written as
+// they were received are comments, so that the output can still be loaded
+func TestZoneEncoderLossyRecords(t *testing.T) {
+	var answers []string
+	for _, rr := range []string{
+		"example.com. 300 IN LOC 52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m",
+		"example.com. 300 IN GPOS -32.6882 116.8652 10.0",
+		"example.com. 300 IN HIP 2 200100107B1A74DF365639CC39F1D578 AwEAAbdxyhNuSutc5EMzxTs9LBPCIkOFH8cIvM4p9+LrV4e19WzK00+CI6zBCQTdtWsuxKbWIy87UOoJTwkUs7lBu+Upr1gsNrut79ryra+bSRGQb1slImA8YVJyuIDsj7kwzG7jnERNqnWxZ48AWkskmdHaVDP4BcelrTI3rMXdXF5D rvs.example.com.",
+		"example.com. 300 IN CSYNC 66 3 A NS AAAA",
+		"example.com. 300 IN CERT PKIX 1 RSASHA256 MxFcby9k/yvedMfQgKzhH5er0Mu/vILz45IkskceFGgiWCn/GxHhai6VAuHAoNUz4YoU1tVfSCSqQYn6//11UA==",
+		"example.com. 300 IN SSHFP 1 1 123456789ABCDEF67890123456789ABCDEF67890",
+		"example.com. 300 IN NSEC3 1 1 12 aabbccdd 2vptu5timamqttgl4luu9kg21e0aor3s A RRSIG",
+		"example.com. 300 IN L64 10 2001:0DB8:1140:1000",
+	} {
+		answers = append(answers, string(rrJSON(t, rr)))
+	}
+	var b bytes.Buffer
+	e := &zoneEncoder{w: &b, module: "ANY"}
+	assert.NilError(t, e.Encode(`{"name":"example.com","status":"NOERROR","data":{"answers":[`+strings.Join(answers, ",")+`]}}`))
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assert.Equal(t, len(lines), 1+len(answers))
+	for i, a := range answers[:len(answers)-1] {
+		assert.Equal(t, lines[1+i], "; "+a)
+	}
+	assert.Equal(t, lines[len(lines)-1], "example.com.\t300\tIN\tL64\t10 2001:0DB8:1140:1000")
+}
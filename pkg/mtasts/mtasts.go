@@ -0,0 +1,294 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package mtasts
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/mxlookup"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// the largest policy that is read (RFC 8461, section 3.3)
+const maxPolicySize = 64 * 1024
+
+// result to be returned by scan of host
+type Result struct {
+	MTASTS *STSRecord    `json:"mta_sts,omitempty" groups:"short,normal,long,trace"`
+	TLSRPT *TLSRPTRecord `json:"tls_rpt,omitempty" groups:"short,normal,long,trace"`
+	// the policy fetched from mta-sts.<domain>, with --mta-sts-fetch-policy
+	Policy *Policy `json:"policy,omitempty" groups:"short,normal,long,trace"`
+	// the MX records of the domain, checked against the mx patterns of the
+	// policy
+	MX []MXMatch `json:"mx,omitempty" groups:"short,normal,long,trace"`
+	// the domain publishes a null MX (RFC 7505), so there are no MX hosts to
+	// check
+	NullMX bool     `json:"null_mx,omitempty" groups:"short,normal,long,trace"`
+	Errors []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// MXMatch is an MX record of the domain, and the policy pattern it matches
+type MXMatch struct {
+	Name       string `json:"name" groups:"short,normal,long,trace"`
+	Preference uint16 `json:"preference" groups:"short,normal,long,trace"`
+	// the domain has no MX records, and this is the domain itself, to which
+	// mail is delivered instead (RFC 5321, section 5.1)
+	Implicit bool   `json:"implicit,omitempty" groups:"short,normal,long,trace"`
+	Pattern  string `json:"pattern,omitempty" groups:"short,normal,long,trace"`
+	Matched  bool   `json:"matched" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	miekg.Lookup
+}
+
+// DoLookup looks up the MTA-STS and TLS-RPT records of name and, with
+// --mta-sts-fetch-policy, fetches the MTA-STS policy and checks the MX
+// records of name against it
+func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	var trace zdns.Trace
+	query := func(qname string, qtype uint16) ([]interface{}, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: qtype, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		return answers(res), status, err
+	}
+	var client *http.Client
+	if s.Factory.Factory.FetchPolicy {
+		client = s.Factory.Factory.HTTPClient
+	}
+	res, status, err := Find(query, name, client)
+	if status != zdns.STATUS_NOERROR {
+		return nil, trace, status, err
+	}
+	return res, trace, status, err
+}
+
+// QueryFunc looks up the records of type qtype at name
+type QueryFunc func(name string, qtype uint16) ([]interface{}, zdns.Status, error)
+
+// Find looks up the MTA-STS and TLS-RPT records of name with query, for
+// modules that check them along with their own lookups. If client isn't nil,
+// the MTA-STS policy is fetched with it, and the MX records of name are
+// checked against it.
+func Find(query QueryFunc, name string, client *http.Client) (Result, zdns.Status, error) {
+	domain := strings.ToLower(strings.TrimSuffix(name, "."))
+	var res Result
+	stsRecords, status, err := findRecords(query, "_mta-sts."+domain, stsPrefixRegexp)
+	if !noRecord(status) {
+		return res, status, err
+	}
+	tlsrptRecords, status, err := findRecords(query, "_smtp._tls."+domain, tlsrptPrefixRegexp)
+	if !noRecord(status) {
+		return res, status, err
+	}
+	if len(stsRecords) == 0 && len(tlsrptRecords) == 0 {
+		return res, zdns.STATUS_NO_RECORD, nil
+	}
+	if len(stsRecords) > 0 {
+		res.MTASTS = ParseSTSRecord(stsRecords[0])
+		if len(stsRecords) > 1 {
+			// senders assume there's no policy then (RFC 8461, section 3.1)
+			res.Errors = append(res.Errors, "more than one MTA-STS record")
+		}
+	}
+	if len(tlsrptRecords) > 0 {
+		res.TLSRPT = ParseTLSRPTRecord(tlsrptRecords[0])
+		if len(tlsrptRecords) > 1 {
+			res.Errors = append(res.Errors, "more than one TLS-RPT record")
+		}
+	}
+	if res.MTASTS != nil && client != nil {
+		res.Policy = fetchPolicy(client, domain)
+		if res.Policy.Version != "" {
+			checkMX(query, &res, domain)
+		}
+	}
+	return res, zdns.STATUS_NOERROR, nil
+}
+
+// noRecord is whether status is a successful lookup, which may not have
+// found a record
+func noRecord(status zdns.Status) bool {
+	return status == zdns.STATUS_NOERROR || status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER
+}
+
+// findRecords returns the TXT records of name that match prefix
+func findRecords(query QueryFunc, name string, prefix *regexp.Regexp) ([]string, zdns.Status, error) {
+	answers, status, err := query(name, dns.TypeTXT)
+	if status != zdns.STATUS_NOERROR {
+		return nil, status, err
+	}
+	var records []string
+	for _, a := range answers {
+		// the strings of a TXT record are joined with newlines by ParseAnswer
+		if ans, ok := a.(miekg.Answer); ok && ans.Type == "TXT" {
+			if record := strings.ReplaceAll(ans.Answer, "\n", ""); prefix.MatchString(record) {
+				records = append(records, record)
+			}
+		}
+	}
+	return records, status, err
+}
+
+// checkMX looks up the MX records of domain, and checks that each is matched
+// by a pattern of the policy. A domain without MX records has the domain
+// itself checked as its implicit MX.
+func checkMX(query QueryFunc, res *Result, domain string) {
+	exchanges, status, err := mxlookup.LookupExchanges(func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		answers, status, err := query(name, qtype)
+		return answers, false, status, err
+	}, domain)
+	if status != zdns.STATUS_NOERROR {
+		res.Errors = append(res.Errors, "MX lookup failed: "+miekg.LookupError(status, err))
+		return
+	}
+	if exchanges.NullMX {
+		res.NullMX = true
+		return
+	}
+	var matches []MXMatch
+	if exchanges.Implicit() {
+		matches = append(matches, MXMatch{Name: domain, Implicit: true})
+	}
+	for _, ans := range exchanges.Records {
+		matches = append(matches, MXMatch{Name: strings.TrimSuffix(ans.Answer.Answer, "."), Preference: ans.Preference})
+	}
+	for _, match := range matches {
+		match.Pattern, match.Matched = res.Policy.MatchMX(match.Name)
+		if !match.Matched && res.Policy.Mode != "none" {
+			res.Errors = append(res.Errors, "MX "+match.Name+" does not match the policy")
+		}
+		res.MX = append(res.MX, match)
+	}
+}
+
+// fetchPolicy fetches the MTA-STS policy of domain over HTTPS with client,
+// which shouldn't follow redirects
+func fetchPolicy(client *http.Client, domain string) *Policy {
+	url := "https://mta-sts." + domain + "/.well-known/mta-sts.txt"
+	failed := func(format string, args ...interface{}) *Policy {
+		return &Policy{URL: url, Errors: []string{fmt.Sprintf(format, args...)}}
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return failed("fetching policy failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return failed("fetching policy failed: HTTP status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPolicySize+1))
+	if err != nil {
+		return failed("fetching policy failed: %v", err)
+	}
+	if len(body) > maxPolicySize {
+		return failed("policy is larger than %d bytes", maxPolicySize)
+	}
+	policy := ParsePolicy(string(body))
+	policy.URL = url
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/plain" {
+		policy.Errors = append(policy.Errors, fmt.Sprintf("invalid media type %q", resp.Header.Get("Content-Type")))
+	}
+	return policy
+}
+
+func answers(res interface{}) []interface{} {
+	r, _ := res.(miekg.Result)
+	return r.Answers
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeTXT, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	FetchPolicy bool
+	// the client policies are fetched with, which doesn't follow redirects
+	HTTPClient *http.Client
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	glf.FetchPolicy, err = f.GetBool("mta-sts-fetch-policy")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (glf *GlobalLookupFactory) Initialize(c *zdns.GlobalConf) error {
+	if err := glf.GlobalLookupFactory.Initialize(c); err != nil {
+		return err
+	}
+	if glf.HTTPClient == nil {
+		glf.HTTPClient = NewPolicyClient(c.Timeout)
+	}
+	return nil
+}
+
+// NewPolicyClient returns a client to fetch policies with, which doesn't
+// follow redirects (RFC 8461, section 3.3)
+func NewPolicyClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	zdns.RegisterLookup("MTASTS", s)
+}
@@ -0,0 +1,219 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package mtasts
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+var mockResults = make(map[string]miekg.Result)
+var queries []QueryRecord
+
+func (s *Lookup) DoMiekgLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if res, ok := mockResults[question.Name]; ok {
+		return res, nil, zdns.STATUS_NOERROR, nil
+	} else {
+		return miekg.Result{}, nil, zdns.STATUS_NXDOMAIN, nil
+	}
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	mockResults = make(map[string]miekg.Result)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func txt(name string, records ...string) miekg.Result {
+	var res miekg.Result
+	for _, record := range records {
+		res.Answers = append(res.Answers, miekg.Answer{Name: name, Type: "TXT", Answer: record})
+	}
+	return res
+}
+
+// policyServer serves policy as the policy of every domain, over HTTPS
+func policyServer(t *testing.T, glf *GlobalLookupFactory, contentType, policy string) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/mta-sts.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(policy))
+	}))
+	t.Cleanup(server.Close)
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return new(net.Dialer).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	// the test certificate is only valid for example.com
+	transport.TLSClientConfig = &tls.Config{RootCAs: transport.TLSClientConfig.RootCAs, ServerName: "example.com"}
+	glf.HTTPClient = &http.Client{Transport: transport}
+}
+
+func TestMtaStsLookup_Records(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "some TXT record", "v=STSv1; id=20220101T000000;")
+	mockResults["_smtp._tls.zdns-testing.com"] = txt("_smtp._tls.zdns-testing.com", "v=TLSRPTv1; rua=mailto:tlsrpt@zdns-testing.com,https://reports.example/tlsrpt")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, len(queries), 2)
+	assert.Equal(t, queries[0].Name, "_mta-sts.zdns-testing.com")
+	assert.Equal(t, queries[0].Type, dns.TypeTXT)
+	assert.Equal(t, queries[1].Name, "_smtp._tls.zdns-testing.com")
+	r := res.(Result)
+	assert.Equal(t, r.MTASTS.ID, "20220101T000000")
+	assert.Equal(t, len(r.MTASTS.Errors), 0)
+	assert.DeepEqual(t, r.TLSRPT.Rua, []string{"mailto:tlsrpt@zdns-testing.com", "https://reports.example/tlsrpt"})
+	assert.Equal(t, len(r.TLSRPT.Errors), 0)
+	assert.Assert(t, r.Policy == nil)
+	assert.Equal(t, len(r.Errors), 0)
+}
+
+func TestMtaStsLookup_NoRecords(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=spf1 -all")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NO_RECORD, status)
+	assert.Equal(t, res, nil)
+}
+
+func TestMtaStsLookup_MultipleRecords(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=STSv1; id=1", "v=STSv1; id=2")
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.DeepEqual(t, res.(Result).Errors, []string{"more than one MTA-STS record"})
+	assert.Assert(t, res.(Result).TLSRPT == nil)
+}
+
+func TestMtaStsLookup_FetchPolicy(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.FetchPolicy = true
+	policyServer(t, glf, "text/plain; charset=utf-8", "version: STSv1\r\nmode: enforce\r\nmx: mx1.zdns-testing.com\r\nmx: *.mail.zdns-testing.com\r\nmax_age: 604800\r\n")
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=STSv1; id=1")
+	mockResults["zdns-testing.com"] = miekg.Result{Answers: []interface{}{
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "mx1.zdns-testing.com."}, Preference: 10},
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "a.mail.zdns-testing.com."}, Preference: 20},
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "a.b.mail.zdns-testing.com."}, Preference: 30},
+	}}
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	assert.Equal(t, queries[2].Name, "zdns-testing.com")
+	assert.Equal(t, queries[2].Type, dns.TypeMX)
+	r := res.(Result)
+	assert.Equal(t, r.Policy.URL, "https://mta-sts.zdns-testing.com/.well-known/mta-sts.txt")
+	assert.Equal(t, r.Policy.Mode, "enforce")
+	assert.Equal(t, r.Policy.MaxAge, int64(604800))
+	assert.DeepEqual(t, r.Policy.MX, []string{"mx1.zdns-testing.com", "*.mail.zdns-testing.com"})
+	assert.Equal(t, len(r.Policy.Errors), 0)
+	assert.DeepEqual(t, r.MX, []MXMatch{
+		{Name: "mx1.zdns-testing.com", Preference: 10, Pattern: "mx1.zdns-testing.com", Matched: true},
+		{Name: "a.mail.zdns-testing.com", Preference: 20, Pattern: "*.mail.zdns-testing.com", Matched: true},
+		{Name: "a.b.mail.zdns-testing.com", Preference: 30},
+	})
+	assert.DeepEqual(t, r.Errors, []string{"MX a.b.mail.zdns-testing.com does not match the policy"})
+}
+
+func TestMtaStsLookup_FetchPolicyNullMX(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.FetchPolicy = true
+	policyServer(t, glf, "text/plain", "version: STSv1\nmode: enforce\nmx: mx1.zdns-testing.com\nmax_age: 604800\n")
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=STSv1; id=1")
+	mockResults["zdns-testing.com"] = miekg.Result{Answers: []interface{}{
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "zdns-testing.com", Type: "MX", Answer: "."}},
+	}}
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, r.NullMX, true)
+	assert.Equal(t, len(r.MX), 0)
+	assert.Equal(t, len(r.Errors), 0)
+}
+
+func TestMtaStsLookup_FetchPolicyImplicitMX(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.FetchPolicy = true
+	policyServer(t, glf, "text/plain", "version: STSv1\nmode: enforce\nmx: mx1.zdns-testing.com\nmax_age: 604800\n")
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=STSv1; id=1")
+	// no MX records
+	mockResults["zdns-testing.com"] = miekg.Result{}
+	res, _, status, _ := l.DoLookup("zdns-testing.com", "")
+	assert.Equal(t, zdns.STATUS_NOERROR, status)
+	r := res.(Result)
+	assert.Equal(t, r.NullMX, false)
+	assert.DeepEqual(t, r.MX, []MXMatch{{Name: "zdns-testing.com", Implicit: true}})
+	assert.DeepEqual(t, r.Errors, []string{"MX zdns-testing.com does not match the policy"})
+}
+
+func TestMtaStsLookup_FetchPolicyMediaType(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.FetchPolicy = true
+	policyServer(t, glf, "text/html", "version: STSv1\nmode: none\nmax_age: 86400\n")
+	mockResults["_mta-sts.zdns-testing.com"] = txt("_mta-sts.zdns-testing.com", "v=STSv1; id=1")
+	res, _, _, _ := l.DoLookup("zdns-testing.com", "")
+	assert.DeepEqual(t, res.(Result).Policy.Errors, []string{`invalid media type "text/html"`})
+}
+
+func TestParseSTSRecord(t *testing.T) {
+	rec := ParseSTSRecord("v=STSv1; id=this-is-not-valid; ext=1")
+	assert.DeepEqual(t, rec.Errors, []string{`invalid id "this-is-not-valid"`})
+	rec = ParseSTSRecord("v=STSv1")
+	assert.DeepEqual(t, rec.Errors, []string{"missing id field"})
+}
+
+func TestParseTLSRPTRecord(t *testing.T) {
+	rec := ParseTLSRPTRecord("v=TLSRPTv1; rua=http://reports.example/,mailto:tlsrpt@example.com")
+	assert.DeepEqual(t, rec.Rua, []string{"mailto:tlsrpt@example.com"})
+	assert.DeepEqual(t, rec.Errors, []string{`invalid rua URI "http://reports.example/": unsupported scheme "http"`})
+	rec = ParseTLSRPTRecord("v=TLSRPTv1;")
+	assert.DeepEqual(t, rec.Errors, []string{"missing rua field"})
+}
+
+func TestParsePolicy(t *testing.T) {
+	p := ParsePolicy("version: STSv1\nmode: sometimes\nmax_age: 99999999\n")
+	assert.DeepEqual(t, p.Errors, []string{`invalid mode "sometimes"`, `invalid max_age "99999999"`, "missing mx field"})
+	p = ParsePolicy("version: STSv1\nmode: none\nmax_age: 0\n")
+	assert.Equal(t, len(p.Errors), 0)
+}
@@ -0,0 +1,243 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package mtasts
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// STSRecord is the _mta-sts TXT record of a domain (RFC 8461, section 3.1)
+type STSRecord struct {
+	Record string   `json:"record" groups:"short,normal,long,trace"`
+	ID     string   `json:"id,omitempty" groups:"short,normal,long,trace"`
+	Errors []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// TLSRPTRecord is the _smtp._tls TXT record of a domain (RFC 8460, section 3)
+type TLSRPTRecord struct {
+	Record string   `json:"record" groups:"short,normal,long,trace"`
+	Rua    []string `json:"rua,omitempty" groups:"short,normal,long,trace"`
+	Errors []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Policy is the MTA-STS policy of a domain (RFC 8461, section 3.2)
+type Policy struct {
+	URL     string   `json:"url" groups:"short,normal,long,trace"`
+	Version string   `json:"version,omitempty" groups:"short,normal,long,trace"`
+	Mode    string   `json:"mode,omitempty" groups:"short,normal,long,trace"`
+	MX      []string `json:"mx,omitempty" groups:"short,normal,long,trace"`
+	MaxAge  int64    `json:"max_age" groups:"short,normal,long,trace"`
+	Errors  []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+}
+
+var (
+	stsPrefixRegexp    = regexp.MustCompile(`^v=STSv1[\t ]*(;|$)`)
+	tlsrptPrefixRegexp = regexp.MustCompile(`^v=TLSRPTv1[\t ]*(;|$)`)
+	stsIDRegexp        = regexp.MustCompile(`^[a-zA-Z0-9]{1,32}$`)
+)
+
+// the longest max_age of a policy, about a year
+const maxPolicyAge = 31557600
+
+// splitFields returns the name=value fields of a TXT record, in order
+func splitFields(record string, errorf func(string, ...interface{})) [][2]string {
+	var fields [][2]string
+	for _, part := range strings.Split(record, ";") {
+		part = strings.Trim(part, " \t")
+		if part == "" {
+			continue
+		}
+		eq := strings.IndexByte(part, '=')
+		if eq < 0 {
+			errorf("invalid field %q", part)
+			continue
+		}
+		fields = append(fields, [2]string{strings.Trim(part[:eq], " \t"), strings.Trim(part[eq+1:], " \t")})
+	}
+	return fields
+}
+
+// ParseSTSRecord parses an MTA-STS TXT record, which starts with v=STSv1
+func ParseSTSRecord(record string) *STSRecord {
+	rec := &STSRecord{Record: record}
+	errorf := func(format string, args ...interface{}) {
+		rec.Errors = append(rec.Errors, fmt.Sprintf(format, args...))
+	}
+	for i, field := range splitFields(record, errorf) {
+		if i == 0 {
+			// the version has been checked by the prefix regexp
+			continue
+		}
+		switch field[0] {
+		case "v":
+			errorf("v field is not first")
+		case "id":
+			if rec.ID != "" {
+				errorf("more than one id field")
+				continue
+			}
+			if !stsIDRegexp.MatchString(field[1]) {
+				errorf("invalid id %q", field[1])
+			}
+			rec.ID = field[1]
+		}
+		// extension fields are ignored
+	}
+	if rec.ID == "" {
+		errorf("missing id field")
+	}
+	return rec
+}
+
+// ParseTLSRPTRecord parses a TLS-RPT TXT record, which starts with
+// v=TLSRPTv1
+func ParseTLSRPTRecord(record string) *TLSRPTRecord {
+	rec := &TLSRPTRecord{Record: record}
+	errorf := func(format string, args ...interface{}) {
+		rec.Errors = append(rec.Errors, fmt.Sprintf(format, args...))
+	}
+	hasRua := false
+	for i, field := range splitFields(record, errorf) {
+		if i == 0 {
+			continue
+		}
+		switch field[0] {
+		case "v":
+			errorf("v field is not first")
+		case "rua":
+			if hasRua {
+				errorf("more than one rua field")
+				continue
+			}
+			hasRua = true
+			for _, uri := range strings.Split(field[1], ",") {
+				uri = strings.Trim(uri, " \t")
+				if err := checkReportURI(uri); err != nil {
+					errorf("invalid rua URI %q: %v", uri, err)
+					continue
+				}
+				rec.Rua = append(rec.Rua, uri)
+			}
+		}
+	}
+	if !hasRua {
+		errorf("missing rua field")
+	}
+	return rec
+}
+
+// checkReportURI checks a TLS-RPT report URI, which is a mailto or https URI
+func checkReportURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		if u.Opaque == "" || !strings.Contains(u.Opaque, "@") {
+			return fmt.Errorf("invalid address %q", u.Opaque)
+		}
+	case "https":
+		if u.Host == "" {
+			return fmt.Errorf("missing host")
+		}
+	default:
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// ParsePolicy parses the text of an MTA-STS policy, which is lines of
+// key: value
+func ParsePolicy(text string) *Policy {
+	p := &Policy{}
+	errorf := func(format string, args ...interface{}) {
+		p.Errors = append(p.Errors, fmt.Sprintf(format, args...))
+	}
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.Trim(line, " \t") == "" {
+			continue
+		}
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			errorf("invalid line %q", line)
+			continue
+		}
+		key, value := strings.Trim(line[:colon], " \t"), strings.Trim(line[colon+1:], " \t")
+		if key != "mx" {
+			if seen[key] {
+				errorf("more than one %s field", key)
+				continue
+			}
+			seen[key] = true
+		}
+		switch key {
+		case "version":
+			if value != "STSv1" {
+				errorf("invalid version %q", value)
+			}
+			p.Version = value
+		case "mode":
+			if value != "enforce" && value != "testing" && value != "none" {
+				errorf("invalid mode %q", value)
+			}
+			p.Mode = value
+		case "max_age":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n < 0 || n > maxPolicyAge {
+				errorf("invalid max_age %q", value)
+				continue
+			}
+			p.MaxAge = n
+		case "mx":
+			p.MX = append(p.MX, value)
+		}
+		// extension fields are ignored
+	}
+	for _, key := range []string{"version", "mode", "max_age"} {
+		if !seen[key] {
+			errorf("missing %s field", key)
+		}
+	}
+	if len(p.MX) == 0 && p.Mode != "none" {
+		errorf("missing mx field")
+	}
+	return p
+}
+
+// MatchMX returns the pattern of the policy that the host name of an MX
+// matches, if any (RFC 8461, section 4.1). A wildcard matches exactly one
+// label.
+func (p *Policy) MatchMX(host string) (string, bool) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range p.MX {
+		pat := strings.ToLower(strings.TrimSuffix(pattern, "."))
+		if strings.HasPrefix(pat, "*.") {
+			dot := strings.IndexByte(host, '.')
+			if dot > 0 && host[dot+1:] == pat[2:] {
+				return pattern, true
+			}
+		} else if host == pat {
+			return pattern, true
+		}
+	}
+	return "", false
+}
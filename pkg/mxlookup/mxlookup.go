@@ -15,12 +15,15 @@
 package mxlookup
 
 import (
+	"net"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/spf13/pflag"
 	"github.com/zmap/dns"
 	"github.com/zmap/zdns/cachehash"
+	"github.com/zmap/zdns/internal/metrics"
 	"github.com/zmap/zdns/pkg/miekg"
 	"github.com/zmap/zdns/pkg/zdns"
 )
@@ -30,6 +33,8 @@ import (
 type CachedAddresses struct {
 	IPv4Addresses []string
 	IPv6Addresses []string
+	// the target of the name, if it is a CNAME
+	CNAME string
 }
 
 type MXRecord struct {
@@ -40,10 +45,21 @@ type MXRecord struct {
 	IPv4Addresses []string `json:"ipv4_addresses,omitempty" groups:"short,normal,long,trace"`
 	IPv6Addresses []string `json:"ipv6_addresses,omitempty" groups:"short,normal,long,trace"`
 	TTL           uint32   `json:"ttl" groups:"ttl,normal,long,trace"`
+	// the domain has no MX records, and this is the domain itself (RFC 5321,
+	// section 5.1)
+	Implicit bool `json:"implicit,omitempty" groups:"short,normal,long,trace"`
+	// the exchange is an IP address rather than a host name, which senders
+	// don't accept
+	IPLiteral bool `json:"ip_literal,omitempty" groups:"short,normal,long,trace"`
+	// the exchange is an alias of this name, which it must not be (RFC 2181,
+	// section 10.3)
+	CNAME string `json:"cname,omitempty" groups:"short,normal,long,trace"`
 }
 
 type Result struct {
 	Servers []MXRecord `json:"exchanges" groups:"short,normal,long,trace"`
+	// the domain publishes a null MX (RFC 7505), and doesn't accept mail
+	NullMX bool `json:"null_mx,omitempty" groups:"short,normal,long,trace"`
 }
 
 // Per Connection Lookup ======================================================
@@ -65,6 +81,7 @@ func (s *Lookup) LookupIPs(l LookupClient, name, nameServer string, lookupIpv4 b
 	// XXX this should be changed to a miekglookup
 	res, found := s.Factory.Factory.CacheHash.Get(name)
 	s.Factory.Factory.CHmu.Unlock()
+	metrics.CacheHit("mx", found)
 	if found {
 		return res.(CachedAddresses), make([]interface{}, 0)
 	}
@@ -73,6 +90,9 @@ func (s *Lookup) LookupIPs(l LookupClient, name, nameServer string, lookupIpv4 b
 	if status == zdns.STATUS_NOERROR && res != nil {
 		retv.IPv4Addresses = res.(miekg.IpResult).IPv4Addresses
 		retv.IPv6Addresses = res.(miekg.IpResult).IPv6Addresses
+		if cnames := res.(miekg.IpResult).CNAMEs; len(cnames) > 0 {
+			retv.CNAME = cnames[0]
+		}
 	}
 
 	s.Factory.Factory.CHmu.Lock()
@@ -83,31 +103,98 @@ func (s *Lookup) LookupIPs(l LookupClient, name, nameServer string, lookupIpv4 b
 
 func (s *Lookup) DoLookup(name, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
 	retv := Result{Servers: []MXRecord{}}
-	res, trace, status, err := s.DoMiekgLookup(miekg.Question{Name: name, Type: dns.TypeMX}, nameServer)
+	var trace zdns.Trace
+	exchanges, status, err := LookupExchanges(func(qname string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: qtype}, nameServer)
+		trace = append(trace, t...)
+		r, _ := res.(miekg.Result)
+		return r.Answers, r.Flags.Authenticated, status, err
+	}, name)
 	if status != zdns.STATUS_NOERROR || err != nil {
 		return nil, trace, status, err
 	}
-	r, ok := res.(miekg.Result)
-	if !ok {
-		return nil, trace, status, err
+	if exchanges.NullMX {
+		retv.NullMX = true
+		return retv, trace, zdns.STATUS_NOERROR, nil
 	}
 	lookupIpv4 := s.Factory.Factory.IPv4Lookup || !s.Factory.Factory.IPv6Lookup
 	lookupIpv6 := s.Factory.Factory.IPv6Lookup
 	l := LookupClient{}
-	for _, ans := range r.Answers {
-		if mxAns, ok := ans.(miekg.PrefAnswer); ok {
-			name = strings.TrimSuffix(mxAns.Answer.Answer, ".")
-			rec := MXRecord{TTL: mxAns.Ttl, Type: mxAns.Type, Class: mxAns.Class, Name: name, Preference: mxAns.Preference}
-			ips, secondTrace := s.LookupIPs(l, name, nameServer, lookupIpv4, lookupIpv6)
+	if exchanges.Implicit() {
+		// mail is delivered to the address records of the domain
+		name = strings.TrimSuffix(name, ".")
+		ips, secondTrace := s.LookupIPs(l, name, nameServer, lookupIpv4, lookupIpv6)
+		trace = append(trace, secondTrace...)
+		if len(ips.IPv4Addresses) > 0 || len(ips.IPv6Addresses) > 0 {
+			rec := MXRecord{Type: "MX", Class: "IN", Name: name, Implicit: true}
 			rec.IPv4Addresses = ips.IPv4Addresses
 			rec.IPv6Addresses = ips.IPv6Addresses
 			retv.Servers = append(retv.Servers, rec)
-			trace = append(trace, secondTrace...)
 		}
+		return retv, trace, zdns.STATUS_NOERROR, nil
+	}
+	for _, mxAns := range exchanges.Records {
+		name = strings.TrimSuffix(mxAns.Answer.Answer, ".")
+		rec := MXRecord{TTL: mxAns.Ttl, Type: mxAns.Type, Class: mxAns.Class, Name: name, Preference: mxAns.Preference}
+		if net.ParseIP(strings.Trim(name, "[]")) != nil {
+			rec.IPLiteral = true
+			retv.Servers = append(retv.Servers, rec)
+			continue
+		}
+		ips, secondTrace := s.LookupIPs(l, name, nameServer, lookupIpv4, lookupIpv6)
+		rec.IPv4Addresses = ips.IPv4Addresses
+		rec.IPv6Addresses = ips.IPv6Addresses
+		rec.CNAME = ips.CNAME
+		retv.Servers = append(retv.Servers, rec)
+		trace = append(trace, secondTrace...)
 	}
 	return retv, trace, zdns.STATUS_NOERROR, nil
 }
 
+// QueryFunc looks up the records of type qtype at name, and whether the
+// answer is DNSSEC-secure
+type QueryFunc func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error)
+
+// Exchanges are the MX records of a domain
+type Exchanges struct {
+	// the MX records by preference, none if the domain has a null MX or no
+	// MX records
+	Records []miekg.PrefAnswer
+	// the domain publishes a null MX (RFC 7505), and doesn't accept mail
+	NullMX bool
+	// the MX RRset is DNSSEC-secure (the resolver set the AD bit)
+	Secure bool
+}
+
+// Implicit is whether the domain has no MX records, so that mail is
+// delivered to the domain itself (RFC 5321, section 5.1)
+func (e Exchanges) Implicit() bool {
+	return !e.NullMX && len(e.Records) == 0
+}
+
+// LookupExchanges looks up the MX records of domain with query, for modules
+// that check the MX hosts of a domain along with their own lookups. A domain
+// without MX records isn't an error.
+func LookupExchanges(query QueryFunc, domain string) (Exchanges, zdns.Status, error) {
+	var e Exchanges
+	answers, secure, status, err := query(domain, dns.TypeMX)
+	if (status != zdns.STATUS_NOERROR && status != zdns.STATUS_NO_ANSWER) || err != nil {
+		return e, status, err
+	}
+	e.Secure = secure
+	for _, ans := range answers {
+		if mxAns, ok := ans.(miekg.PrefAnswer); ok {
+			e.Records = append(e.Records, mxAns)
+		}
+	}
+	if len(e.Records) == 1 && strings.TrimSuffix(e.Records[0].Answer.Answer, ".") == "" {
+		e.Records, e.NullMX = nil, true
+		return e, zdns.STATUS_NOERROR, nil
+	}
+	sort.SliceStable(e.Records, func(i, j int) bool { return e.Records[i].Preference < e.Records[j].Preference })
+	return e, zdns.STATUS_NOERROR, nil
+}
+
 // Per GoRoutine Factory ======================================================
 //
 type RoutineLookupFactory struct {
@@ -50,6 +50,7 @@ func (s *Lookup) DoTargetedLookup(l LookupClient, name, nameServer string, looku
 		if lookupIpv6 {
 			retv.IPv6Addresses = res.IPv6Addresses
 		}
+		retv.CNAMEs = res.CNAMEs
 		return retv, nil, protocolStatus, nil
 	} else {
 		return retv, nil, zdns.STATUS_NXDOMAIN, nil
@@ -299,6 +300,114 @@ func TestErrorInTargetedLookup(t *testing.T) {
 	verifyResult(t, res.(Result).Servers, expectedServersMap)
 }
 
+func mxAnswer(exchange string, preference uint16) miekg.PrefAnswer {
+	return miekg.PrefAnswer{
+		Answer: miekg.Answer{
+			Ttl:    3600,
+			Type:   "MX",
+			Class:  "IN",
+			Name:   "example.com.",
+			Answer: exchange,
+		},
+		Preference: preference,
+	}
+}
+
+func TestImplicitMx(t *testing.T) {
+	_, _, _, l := InitTest(t)
+
+	mxResults["example.com"] = miekg.Result{}
+	mockResults["example.com"] = miekg.IpResult{
+		IPv4Addresses: []string{"192.0.2.1"},
+	}
+
+	res, _, status, _ := l.DoLookup("example.com", "")
+	if status != zdns.STATUS_NOERROR {
+		t.Errorf("Expected STATUS_NOERROR status, got %v", status)
+	}
+	servers := res.(Result).Servers
+	if len(servers) != 1 || !servers[0].Implicit || servers[0].Name != "example.com" || servers[0].Preference != 0 {
+		t.Errorf("Expected implicit MX example.com, found %v", servers)
+	}
+	verifyResult(t, servers, map[string]minimalServerRecords{
+		"example.com": {recType: "MX", IPv4Addresses: []string{"192.0.2.1"}},
+	})
+}
+
+func TestNoImplicitMx(t *testing.T) {
+	_, _, _, l := InitTest(t)
+
+	mxResults["example.com"] = miekg.Result{}
+
+	res, _, status, _ := l.DoLookup("example.com", "")
+	if status != zdns.STATUS_NOERROR {
+		t.Errorf("Expected STATUS_NOERROR status, got %v", status)
+	}
+	if len(res.(Result).Servers) != 0 {
+		t.Errorf("Expected no exchanges without address records, found %v", res.(Result).Servers)
+	}
+}
+
+func TestNullMx(t *testing.T) {
+	_, _, _, l := InitTest(t)
+
+	mxResults["example.com"] = miekg.Result{
+		Answers: []interface{}{mxAnswer(".", 0)},
+	}
+	mockResults["example.com"] = miekg.IpResult{
+		IPv4Addresses: []string{"192.0.2.1"},
+	}
+
+	res, _, status, _ := l.DoLookup("example.com", "")
+	if status != zdns.STATUS_NOERROR {
+		t.Errorf("Expected STATUS_NOERROR status, got %v", status)
+	}
+	if !res.(Result).NullMX {
+		t.Error("Expected null MX")
+	}
+	if len(res.(Result).Servers) != 0 {
+		t.Errorf("Expected no exchanges for null MX, found %v", res.(Result).Servers)
+	}
+}
+
+func TestMxSortedAndFlagged(t *testing.T) {
+	_, _, _, l := InitTest(t)
+
+	mxResults["example.com"] = miekg.Result{
+		Answers: []interface{}{
+			mxAnswer("alias.example.com.", 30),
+			mxAnswer("192.0.2.9", 20),
+			mxAnswer("mail.example.com.", 10),
+		},
+	}
+	mockResults["mail.example.com"] = miekg.IpResult{
+		IPv4Addresses: []string{"192.0.2.1"},
+	}
+	mockResults["alias.example.com"] = miekg.IpResult{
+		IPv4Addresses: []string{"192.0.2.1"},
+		CNAMEs:        []string{"mail.example.com"},
+	}
+
+	res, _, _, _ := l.DoLookup("example.com", "")
+	servers := res.(Result).Servers
+	var names []string
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"mail.example.com", "192.0.2.9", "alias.example.com"}) {
+		t.Errorf("Expected exchanges sorted by preference, found %v", names)
+	}
+	if servers[0].IPLiteral || servers[0].CNAME != "" {
+		t.Errorf("Expected mail.example.com not to be flagged, found %v", servers[0])
+	}
+	if !servers[1].IPLiteral || servers[1].IPv4Addresses != nil {
+		t.Errorf("Expected 192.0.2.9 to be flagged as an IP literal, found %v", servers[1])
+	}
+	if servers[2].CNAME != "mail.example.com" {
+		t.Errorf("Expected alias.example.com to be flagged as a CNAME, found %v", servers[2])
+	}
+}
+
 func verifyResult(t *testing.T, servers []MXRecord, expectedServersMap map[string]minimalServerRecords) {
 	serversLength := len(servers)
 	expectedServersLength := len(expectedServersMap)
@@ -321,3 +430,42 @@ func verifyResult(t *testing.T, servers []MXRecord, expectedServersMap map[strin
 		}
 	}
 }
+
+func TestLookupExchanges(t *testing.T) {
+	answers := []interface{}{mxAnswer("mx2.example.com.", 20), mxAnswer("mx1.example.com.", 10)}
+	exchanges, status, _ := LookupExchanges(func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		if name != "example.com" || qtype != dns.TypeMX {
+			t.Errorf("Unexpected lookup of %s (%d)", name, qtype)
+		}
+		return answers, true, zdns.STATUS_NOERROR, nil
+	}, "example.com")
+	if status != zdns.STATUS_NOERROR || !exchanges.Secure || exchanges.Implicit() || exchanges.NullMX {
+		t.Errorf("Expected secure MX records, found %v (%v)", exchanges, status)
+	}
+	if len(exchanges.Records) != 2 || exchanges.Records[0].Preference != 10 {
+		t.Errorf("Expected MX records sorted by preference, found %v", exchanges.Records)
+	}
+
+	exchanges, status, _ = LookupExchanges(func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		return nil, false, zdns.STATUS_NO_ANSWER, nil
+	}, "example.com")
+	if status != zdns.STATUS_NOERROR || !exchanges.Implicit() || exchanges.Secure {
+		t.Errorf("Expected an insecure implicit MX, found %v (%v)", exchanges, status)
+	}
+
+	_, status, _ = LookupExchanges(func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		return nil, false, zdns.STATUS_SERVFAIL, nil
+	}, "example.com")
+	if status != zdns.STATUS_SERVFAIL {
+		t.Errorf("Expected SERVFAIL, found %v", status)
+	}
+}
+
+func TestLookupExchangesNullMx(t *testing.T) {
+	exchanges, status, _ := LookupExchanges(func(name string, qtype uint16) ([]interface{}, bool, zdns.Status, error) {
+		return []interface{}{miekg.Answer{Name: "example.com", Type: "CNAME", Answer: "mail.example.com."}, mxAnswer(".", 0)}, false, zdns.STATUS_NOERROR, nil
+	}, "example.com")
+	if status != zdns.STATUS_NOERROR || !exchanges.NullMX || exchanges.Implicit() || len(exchanges.Records) != 0 {
+		t.Errorf("Expected a null MX without records, found %v (%v)", exchanges, status)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package soacheck
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/nslookup"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// ServerResult is the SOA answer of one address of a name server
+type ServerResult struct {
+	Name    string `json:"name" groups:"short,normal,long,trace"`
+	Address string `json:"address" groups:"short,normal,long,trace"`
+	Status  string `json:"status" groups:"short,normal,long,trace"`
+	// the rcode of the response, which is empty if there was none (e.g., a
+	// timeout)
+	Rcode         string  `json:"rcode,omitempty" groups:"short,normal,long,trace"`
+	Authoritative bool    `json:"authoritative" groups:"short,normal,long,trace"`
+	Serial        *uint32 `json:"serial,omitempty" groups:"short,normal,long,trace"`
+	// the serial is older than the newest serial of the zone
+	Stale bool `json:"stale,omitempty" groups:"short,normal,long,trace"`
+	// the time of the query in seconds, including retries
+	Duration float64 `json:"duration" groups:"short,normal,long,trace"`
+	Error    string  `json:"error,omitempty" groups:"short,normal,long,trace"`
+}
+
+// result to be returned by scan of host
+type Result struct {
+	Servers []ServerResult `json:"servers,omitempty" groups:"short,normal,long,trace"`
+	// the distinct serials of the servers, newest first
+	Serials []uint32 `json:"serials,omitempty" groups:"short,normal,long,trace"`
+	// whether the servers that answered all have the same serial
+	Consistent bool `json:"consistent" groups:"short,normal,long,trace"`
+}
+
+// Per Connection Lookup ======================================================
+type Lookup struct {
+	Factory *RoutineLookupFactory
+	nslookup.Lookup
+}
+
+// DoLookup looks up the name servers of the zone name and their addresses,
+// and queries the SOA record of the zone at each address, without recursion
+func (s *Lookup) DoLookup(name, nameServer string) (interface{}, zdns.Trace, zdns.Status, error) {
+	lookupIpv4 := s.Factory.Factory.IPv4Lookup || !s.Factory.Factory.IPv6Lookup
+	lookupIpv6 := s.Factory.Factory.IPv6Lookup || !s.Factory.Factory.IPv4Lookup
+	ns, trace, status, err := s.DoNSLookup(name, lookupIpv4, lookupIpv6, nameServer)
+	if status != zdns.STATUS_NOERROR {
+		return nil, trace, status, err
+	}
+	if len(ns.Servers) == 0 {
+		return nil, trace, zdns.STATUS_NO_RECORD, nil
+	}
+	var res Result
+	for _, server := range ns.Servers {
+		addresses := append(append([]string(nil), server.IPv4Addresses...), server.IPv6Addresses...)
+		if len(addresses) == 0 {
+			res.Servers = append(res.Servers, ServerResult{Name: server.Name, Status: string(zdns.STATUS_NO_RECORD), Error: "no addresses"})
+		}
+		for _, address := range addresses {
+			r, t := s.querySOA(name, server.Name, address)
+			trace = append(trace, t...)
+			res.Servers = append(res.Servers, r)
+		}
+	}
+	compareSerials(&res)
+	return res, trace, zdns.STATUS_NOERROR, nil
+}
+
+// querySOA queries the SOA record of zone at address
+func (s *Lookup) querySOA(zone, server, address string) (ServerResult, zdns.Trace) {
+	r := ServerResult{Name: server, Address: address}
+	start := time.Now()
+	res, trace, status, err := s.DoDirectLookup(miekg.Question{Name: zone, Type: dns.TypeSOA, Class: dns.ClassINET}, net.JoinHostPort(address, "53"))
+	r.Duration = time.Since(start).Seconds()
+	r.Status = string(status)
+	if _, ok := dns.StringToRcode[string(status)]; ok {
+		r.Rcode = string(status)
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if status != zdns.STATUS_NOERROR {
+		return r, trace
+	}
+	result := result(res)
+	r.Authoritative = result.Flags.Authoritative
+	for _, a := range result.Answers {
+		if soa, ok := a.(miekg.SOAAnswer); ok && strings.EqualFold(strings.TrimSuffix(soa.Name, "."), strings.TrimSuffix(zone, ".")) {
+			serial := soa.Serial
+			r.Serial = &serial
+			break
+		}
+	}
+	if r.Serial == nil {
+		// e.g., a referral from a server that isn't authoritative for the zone
+		r.Status = string(zdns.STATUS_NO_ANSWER)
+	}
+	return r, trace
+}
+
+// compareSerials sets the distinct serials of the servers, newest first, and
+// marks servers with older serials as stale
+func compareSerials(res *Result) {
+	seen := make(map[uint32]bool)
+	for _, r := range res.Servers {
+		if r.Serial != nil && !seen[*r.Serial] {
+			seen[*r.Serial] = true
+			res.Serials = append(res.Serials, *r.Serial)
+		}
+	}
+	// serials wrap around (RFC 1982), so the order isn't that of the numbers
+	for i := 1; i < len(res.Serials); i++ {
+		for j := i; j > 0 && serialLess(res.Serials[j-1], res.Serials[j]); j-- {
+			res.Serials[j-1], res.Serials[j] = res.Serials[j], res.Serials[j-1]
+		}
+	}
+	res.Consistent = len(res.Serials) == 1
+	for i, r := range res.Servers {
+		if r.Serial != nil && *r.Serial != res.Serials[0] {
+			res.Servers[i].Stale = true
+		}
+	}
+}
+
+// serialLess is whether serial a is older than b (RFC 1982, section 3.2)
+func serialLess(a, b uint32) bool {
+	return a != b && int32(b-a) > 0
+}
+
+func result(res interface{}) miekg.Result {
+	r, _ := res.(miekg.Result)
+	return r
+}
+
+// Per GoRoutine Factory ======================================================
+type RoutineLookupFactory struct {
+	miekg.RoutineLookupFactory
+	Factory *GlobalLookupFactory
+}
+
+func (rlf *RoutineLookupFactory) MakeLookup() (zdns.Lookup, error) {
+	lookup := Lookup{Factory: rlf}
+	nameServer := rlf.Factory.RandomNameServer()
+	lookup.Initialize(nameServer, dns.TypeSOA, dns.ClassINET, &rlf.RoutineLookupFactory)
+	return &lookup, nil
+}
+
+// Global Factory =============================================================
+type GlobalLookupFactory struct {
+	miekg.GlobalLookupFactory
+	IPv4Lookup bool
+	IPv6Lookup bool
+}
+
+func (glf *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	glf.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	glf.IPv4Lookup, err = f.GetBool("ipv4-lookup")
+	if err != nil {
+		panic(err)
+	}
+	glf.IPv6Lookup, err = f.GetBool("ipv6-lookup")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Command-line Help Documentation. This is the descriptive text what is
+// returned when you run zdns module --help
+func (glf *GlobalLookupFactory) Help() string {
+	return ""
+}
+
+func (glf *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
+	rlf := new(RoutineLookupFactory)
+	rlf.RoutineLookupFactory.Factory = &glf.GlobalLookupFactory
+	rlf.Initialize(glf.GlobalConf)
+	rlf.Factory = glf
+	rlf.ThreadID = threadID
+	return rlf, nil
+}
+
+// Global Registration ========================================================
+func init() {
+	s := new(GlobalLookupFactory)
+	zdns.RegisterLookup("SOACHECK", s)
+}
@@ -0,0 +1,196 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package soacheck
+
+import (
+	"testing"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/nslookup"
+	"github.com/zmap/zdns/pkg/zdns"
+	"gotest.tools/v3/assert"
+)
+
+type QueryRecord struct {
+	miekg.Question
+	NameServer string
+}
+
+type mockResponse struct {
+	res    miekg.Result
+	status zdns.Status
+}
+
+var mockNS nslookup.Result
+var mockNSStatus zdns.Status
+var nsLookups []bool
+var mockResponses = make(map[string]mockResponse)
+var queries []QueryRecord
+
+func (s *Lookup) DoNSLookup(name string, lookupIpv4 bool, lookupIpv6 bool, nameServer string) (nslookup.Result, zdns.Trace, zdns.Status, error) {
+	nsLookups = []bool{lookupIpv4, lookupIpv6}
+	return mockNS, nil, mockNSStatus, nil
+}
+
+func (s *Lookup) DoDirectLookup(question miekg.Question, nameServer string) (miekg.Result, []interface{}, zdns.Status, error) {
+	queries = append(queries, QueryRecord{Question: question, NameServer: nameServer})
+	if r, ok := mockResponses[nameServer]; ok {
+		return r.res, nil, r.status, nil
+	}
+	return miekg.Result{}, nil, zdns.STATUS_TIMEOUT, nil
+}
+
+func InitTest() (*zdns.GlobalConf, *GlobalLookupFactory, *RoutineLookupFactory, zdns.Lookup) {
+	queries = nil
+	nsLookups = nil
+	mockNS = nslookup.Result{}
+	mockNSStatus = zdns.STATUS_NOERROR
+	mockResponses = make(map[string]mockResponse)
+	gc := new(zdns.GlobalConf)
+	gc.NameServers = []string{"127.0.0.1"}
+
+	glf := new(GlobalLookupFactory)
+	glf.GlobalConf = gc
+
+	rlf := new(RoutineLookupFactory)
+	rlf.Factory = glf
+
+	l, err := rlf.MakeLookup()
+	if l == nil || err != nil {
+		panic("Failed to initialize lookup")
+	}
+	return gc, glf, rlf, l
+}
+
+func server(name string, ipv4 []string, ipv6 []string) nslookup.NSRecord {
+	return nslookup.NSRecord{Name: name, Type: "NS", IPv4Addresses: ipv4, IPv6Addresses: ipv6}
+}
+
+func mockSOA(address, zone string, serial uint32, authoritative bool) {
+	soa := miekg.SOAAnswer{Answer: miekg.Answer{Name: zone, Type: "SOA"}, Ns: "ns1." + zone, Serial: serial}
+	mockResponses[address] = mockResponse{miekg.Result{Answers: []interface{}{soa}, Flags: miekg.DNSFlags{Authoritative: authoritative}}, zdns.STATUS_NOERROR}
+}
+
+func TestConsistent(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockNS.Servers = []nslookup.NSRecord{
+		server("ns1.example.com", []string{"192.0.2.1"}, []string{"2001:db8::1"}),
+		server("ns2.example.com", []string{"192.0.2.2"}, nil),
+	}
+	mockSOA("192.0.2.1:53", "example.com", 2024010101, true)
+	mockSOA("[2001:db8::1]:53", "example.com", 2024010101, true)
+	mockSOA("192.0.2.2:53", "example.com", 2024010101, true)
+	res, _, status, err := l.DoLookup("example.com", "")
+	assert.NilError(t, err)
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	r := res.(Result)
+	assert.Assert(t, r.Consistent)
+	assert.DeepEqual(t, r.Serials, []uint32{2024010101})
+	assert.Equal(t, len(r.Servers), 3)
+	assert.Equal(t, r.Servers[1].Address, "2001:db8::1")
+	for _, s := range r.Servers {
+		assert.Equal(t, s.Status, "NOERROR")
+		assert.Equal(t, s.Rcode, "NOERROR")
+		assert.Assert(t, s.Authoritative)
+		assert.Equal(t, *s.Serial, uint32(2024010101))
+		assert.Assert(t, !s.Stale)
+	}
+	assert.DeepEqual(t, nsLookups, []bool{true, true})
+	for _, q := range queries {
+		assert.Equal(t, q.Type, dns.TypeSOA)
+		assert.Equal(t, q.Name, "example.com")
+	}
+}
+
+func TestStaleSecondary(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockNS.Servers = []nslookup.NSRecord{
+		server("ns1.example.com", []string{"192.0.2.1"}, nil),
+		server("ns2.example.com", []string{"192.0.2.2"}, nil),
+	}
+	mockSOA("192.0.2.1:53", "example.com", 41, true)
+	mockSOA("192.0.2.2:53", "example.com", 42, true)
+	res, _, _, _ := l.DoLookup("example.com", "")
+	r := res.(Result)
+	assert.Assert(t, !r.Consistent)
+	assert.DeepEqual(t, r.Serials, []uint32{42, 41})
+	assert.Assert(t, r.Servers[0].Stale)
+	assert.Assert(t, !r.Servers[1].Stale)
+}
+
+func TestSerialWrapAround(t *testing.T) {
+	assert.Assert(t, serialLess(4294967295, 1))
+	assert.Assert(t, !serialLess(1, 4294967295))
+	res := Result{Servers: []ServerResult{{Serial: new(uint32)}, {Serial: new(uint32)}}}
+	*res.Servers[0].Serial = 4294967290
+	*res.Servers[1].Serial = 5
+	compareSerials(&res)
+	assert.DeepEqual(t, res.Serials, []uint32{5, 4294967290})
+	assert.Assert(t, res.Servers[0].Stale)
+}
+
+func TestFailingServers(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockNS.Servers = []nslookup.NSRecord{
+		server("ns1.example.com", []string{"192.0.2.1"}, nil),
+		server("ns2.example.com", []string{"192.0.2.2"}, nil),
+		server("ns3.example.com", []string{"192.0.2.3"}, nil),
+		server("ns4.example.net", nil, nil),
+	}
+	mockSOA("192.0.2.1:53", "example.com", 7, true)
+	mockResponses["192.0.2.2:53"] = mockResponse{status: zdns.STATUS_REFUSED}
+	res, _, status, _ := l.DoLookup("example.com", "")
+	assert.Equal(t, status, zdns.STATUS_NOERROR)
+	r := res.(Result)
+	assert.Assert(t, r.Consistent)
+	assert.Equal(t, r.Servers[1].Status, "REFUSED")
+	assert.Equal(t, r.Servers[1].Rcode, "REFUSED")
+	assert.Assert(t, r.Servers[1].Serial == nil)
+	assert.Equal(t, r.Servers[2].Status, "TIMEOUT")
+	assert.Equal(t, r.Servers[2].Rcode, "")
+	assert.Equal(t, r.Servers[3].Error, "no addresses")
+}
+
+func TestLameServer(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockNS.Servers = []nslookup.NSRecord{server("ns1.example.com", []string{"192.0.2.1"}, nil)}
+	// a referral rather than an answer
+	mockResponses["192.0.2.1:53"] = mockResponse{miekg.Result{}, zdns.STATUS_NOERROR}
+	res, _, _, _ := l.DoLookup("example.com", "")
+	r := res.(Result)
+	assert.Equal(t, r.Servers[0].Status, "NO_ANSWER")
+	assert.Assert(t, !r.Servers[0].Authoritative)
+	assert.Assert(t, !r.Consistent)
+}
+
+func TestAddressFamilies(t *testing.T) {
+	_, glf, _, l := InitTest()
+	glf.IPv6Lookup = true
+	mockNS.Servers = []nslookup.NSRecord{server("ns1.example.com", nil, nil)}
+	l.DoLookup("example.com", "")
+	assert.DeepEqual(t, nsLookups, []bool{false, true})
+}
+
+func TestNSLookupFailure(t *testing.T) {
+	_, _, _, l := InitTest()
+	mockNSStatus = zdns.STATUS_SERVFAIL
+	res, _, status, _ := l.DoLookup("example.com", "")
+	assert.Equal(t, status, zdns.STATUS_SERVFAIL)
+	assert.Assert(t, res == nil)
+	mockNSStatus = zdns.STATUS_NOERROR
+	_, _, status, _ = l.DoLookup("www.example.com", "")
+	assert.Equal(t, status, zdns.STATUS_NO_RECORD)
+}
@@ -0,0 +1,769 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package spf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/pkg/miekg"
+	"github.com/zmap/zdns/pkg/zdns"
+)
+
+// Limits of RFC 7208, section 4.6.4
+const (
+	maxDNSLookups  = 10
+	maxVoidLookups = 2
+	// MX and PTR names looked up for a single mechanism
+	maxNames = 10
+	// nesting of include and redirect, to stop loops that the lookup limit
+	// doesn't, since the whole tree is expanded
+	maxNesting = 10
+	// terms expanded, past the lookup limit so that the number of lookups
+	// over it is reported, but bounded, since the tree can grow exponentially
+	// with include
+	maxExpandedLookups = 2 * maxDNSLookups
+)
+
+// Results of check_host()
+const (
+	ResultNone      = "none"
+	ResultNeutral   = "neutral"
+	ResultPass      = "pass"
+	ResultFail      = "fail"
+	ResultSoftfail  = "softfail"
+	ResultTemperror = "temperror"
+	ResultPermerror = "permerror"
+)
+
+// Evaluation is the SPF policy of a domain, expanded through its include and
+// redirect terms
+type Evaluation struct {
+	// the result of check_host() for --spf-ip
+	Result string `json:"result,omitempty" groups:"short,normal,long,trace"`
+	IP     string `json:"ip,omitempty" groups:"short,normal,long,trace"`
+	// terms that cause DNS lookups and lookups that returned nothing, over the
+	// whole tree rather than up to the first match, so that records over the
+	// limits are found whatever the sender
+	DNSLookups  int `json:"dns_lookups" groups:"short,normal,long,trace"`
+	VoidLookups int `json:"void_lookups" groups:"short,normal,long,trace"`
+	// problems that make check_host() return permerror or temperror, for some
+	// or every sender
+	Errors []string `json:"errors,omitempty" groups:"short,normal,long,trace"`
+	Record *Record  `json:"record,omitempty" groups:"short,normal,long,trace"`
+}
+
+// Record is the SPF record of a domain
+type Record struct {
+	Domain string `json:"domain" groups:"short,normal,long,trace"`
+	Text   string `json:"text,omitempty" groups:"short,normal,long,trace"`
+	Terms  []Term `json:"terms,omitempty" groups:"short,normal,long,trace"`
+	Error  string `json:"error,omitempty" groups:"short,normal,long,trace"`
+	// the result of check_host() if the record can't be evaluated
+	result string
+}
+
+// Term is a mechanism or modifier of a record
+type Term struct {
+	Qualifier string `json:"qualifier,omitempty" groups:"short,normal,long,trace"`
+	Mechanism string `json:"mechanism,omitempty" groups:"short,normal,long,trace"`
+	Modifier  string `json:"modifier,omitempty" groups:"short,normal,long,trace"`
+	// the argument as written, e.g., the domain-spec or network
+	Value string `json:"value,omitempty" groups:"short,normal,long,trace"`
+	// the domain that was looked up, after macro expansion
+	Target string `json:"target,omitempty" groups:"short,normal,long,trace"`
+	// the record of include and redirect
+	Record *Record `json:"record,omitempty" groups:"short,normal,long,trace"`
+	Error  string  `json:"error,omitempty" groups:"short,normal,long,trace"`
+
+	domainSpec   string
+	cidr4, cidr6 int
+	network      *net.IPNet
+	// addresses found for a, mx and exists, and the validated names of ptr
+	addrs []net.IP
+	names []string
+	// the lookup of the term returned nothing
+	void bool
+	// temperror or permerror, if the lookups of the term failed
+	result string
+}
+
+func (t *Term) lookups() bool {
+	switch t.Mechanism {
+	case "include", "a", "mx", "ptr", "exists":
+		return true
+	}
+	return t.Modifier == "redirect"
+}
+
+// QueryFunc looks up the records of type qtype at name
+type QueryFunc func(name string, qtype uint16) ([]interface{}, zdns.Status, error)
+
+type cachedQuery struct {
+	answers []interface{}
+	status  zdns.Status
+	err     error
+}
+
+type cacheKey struct {
+	name  string
+	qtype uint16
+}
+
+// evaluator expands the tree of a policy, with the lookups of every term
+type evaluator struct {
+	query QueryFunc
+	cache map[cacheKey]cachedQuery
+	ip    net.IP
+	// the sender and HELO domain used for macros
+	sender, helo string
+	eval         *Evaluation
+}
+
+func newEvaluator(query QueryFunc, ip net.IP, sender, domain string) *evaluator {
+	if sender == "" {
+		sender = "postmaster@" + domain
+	} else if !strings.Contains(sender, "@") {
+		sender = "postmaster@" + sender
+	} else if strings.HasPrefix(sender, "@") {
+		sender = "postmaster" + sender
+	}
+	return &evaluator{
+		query:  query,
+		cache:  make(map[cacheKey]cachedQuery),
+		ip:     ip,
+		sender: sender,
+		helo:   domain,
+		eval:   new(Evaluation),
+	}
+}
+
+// seed adds the result of a lookup that was already made to the cache
+func (e *evaluator) seed(name string, qtype uint16, answers []interface{}, status zdns.Status) {
+	e.cache[cacheKey{strings.ToLower(name), qtype}] = cachedQuery{answers: answers, status: status}
+}
+
+// lookup returns the answers of type qtype at name. Void lookups are counted
+// if the lookup is made by a term.
+func (e *evaluator) lookup(name string, qtype uint16, term bool) (answers []interface{}, void bool, err error) {
+	key := cacheKey{strings.ToLower(name), qtype}
+	c, ok := e.cache[key]
+	if !ok {
+		c.answers, c.status, c.err = e.query(name, qtype)
+		e.cache[key] = c
+	}
+	switch c.status {
+	case zdns.STATUS_NOERROR:
+		typ := dns.TypeToString[qtype]
+		for _, a := range c.answers {
+			if answerType(a) == typ {
+				answers = append(answers, a)
+			}
+		}
+	case zdns.STATUS_NXDOMAIN, zdns.STATUS_NO_ANSWER, zdns.STATUS_NODATA:
+	default:
+		if c.err != nil {
+			return nil, false, fmt.Errorf("%s %s: %s: %v", name, dns.TypeToString[qtype], c.status, c.err)
+		}
+		return nil, false, fmt.Errorf("%s %s: %s", name, dns.TypeToString[qtype], c.status)
+	}
+	if len(answers) == 0 {
+		void = true
+		if term {
+			e.eval.VoidLookups++
+		}
+	}
+	return answers, void, nil
+}
+
+func answerType(a interface{}) string {
+	switch a := a.(type) {
+	case miekg.Answer:
+		return a.Type
+	case miekg.PrefAnswer:
+		return a.Type
+	}
+	return ""
+}
+
+func answerString(a interface{}) string {
+	switch a := a.(type) {
+	case miekg.Answer:
+		return a.Answer
+	case miekg.PrefAnswer:
+		return a.Answer.Answer
+	}
+	return ""
+}
+
+// EvaluateDomain expands the SPF policy of domain with query, for modules
+// that evaluate SPF along with their own lookups. ip and sender are as for
+// --spf-ip and --spf-sender.
+func EvaluateDomain(query QueryFunc, domain string, ip net.IP, sender string) *Evaluation {
+	return newEvaluator(query, ip, sender, domain).evaluate(domain)
+}
+
+// evaluate expands the policy of domain, and runs check_host() on it if the
+// sender IP is known
+func (e *evaluator) evaluate(domain string) *Evaluation {
+	ev := e.eval
+	ev.Record = e.record(domain, nil)
+	if ev.DNSLookups > maxDNSLookups {
+		ev.Errors = append(ev.Errors, fmt.Sprintf("%d DNS lookups, more than the limit of %d", ev.DNSLookups, maxDNSLookups))
+	}
+	if ev.VoidLookups > maxVoidLookups {
+		ev.Errors = append(ev.Errors, fmt.Sprintf("%d void lookups, more than the limit of %d", ev.VoidLookups, maxVoidLookups))
+	}
+	if e.ip != nil {
+		ev.IP = e.ip.String()
+		c := checker{ip: e.ip}
+		ev.Result = c.check(ev.Record)
+	}
+	return ev
+}
+
+// errorf records a problem with the record of domain
+func (e *evaluator) errorf(domain, format string, args ...interface{}) string {
+	msg := fmt.Sprintf(format, args...)
+	e.eval.Errors = append(e.eval.Errors, domain+": "+msg)
+	return msg
+}
+
+// record fetches and expands the record of domain. path holds the domains
+// that include domain.
+func (e *evaluator) record(domain string, path []string) *Record {
+	rec := &Record{Domain: domain}
+	for _, d := range path {
+		if strings.EqualFold(d, domain) {
+			rec.Error = e.errorf(domain, "include loop")
+			rec.result = ResultPermerror
+			return rec
+		}
+	}
+	if len(path) > maxNesting {
+		rec.Error = e.errorf(domain, "nested more than %d times", maxNesting)
+		rec.result = ResultPermerror
+		return rec
+	}
+	answers, _, err := e.lookup(domain, dns.TypeTXT, false)
+	if err != nil {
+		rec.Error = e.errorf(domain, "%v", err)
+		rec.result = ResultTemperror
+		return rec
+	}
+	var records []string
+	for _, a := range answers {
+		// the strings of a TXT record are joined with newlines by ParseAnswer
+		txt := strings.ReplaceAll(answerString(a), "\n", "")
+		if isSPF(txt) {
+			records = append(records, txt)
+		}
+	}
+	switch len(records) {
+	case 0:
+		rec.Error = "no SPF record"
+		rec.result = ResultNone
+		if len(path) > 0 {
+			e.errorf(domain, "no SPF record")
+		}
+		return rec
+	case 1:
+	default:
+		rec.Error = e.errorf(domain, "%d SPF records", len(records))
+		rec.result = ResultPermerror
+		return rec
+	}
+	rec.Text = records[0]
+	terms, err := parseRecord(rec.Text)
+	if err != nil {
+		rec.Error = e.errorf(domain, "%v", err)
+		rec.result = ResultPermerror
+		return rec
+	}
+	rec.Terms = terms
+	hasAll := false
+	for _, t := range terms {
+		hasAll = hasAll || t.Mechanism == "all"
+	}
+	path = append(path, domain)
+	for i := range rec.Terms {
+		t := &rec.Terms[i]
+		// redirect is ignored if there is an all mechanism
+		if t.Modifier == "redirect" && hasAll {
+			continue
+		}
+		if t.lookups() {
+			e.eval.DNSLookups++
+			if e.eval.DNSLookups > maxExpandedLookups {
+				if e.eval.DNSLookups == maxExpandedLookups+1 {
+					e.errorf(domain, "stopped expanding the policy after %d DNS lookups", maxExpandedLookups)
+				}
+				t.Error = "not expanded"
+				t.result = ResultPermerror
+				continue
+			}
+			e.expand(t, domain, path)
+		}
+	}
+	return rec
+}
+
+// expand makes the lookups of term t of the record of domain
+func (e *evaluator) expand(t *Term, domain string, path []string) {
+	target := domain
+	if t.domainSpec != "" {
+		var err error
+		target, err = expandDomain(t.domainSpec, e.macroContext(domain))
+		if err == errUnknownSender {
+			t.Error = err.Error()
+			return
+		} else if err != nil {
+			t.Error = e.errorf(domain, "%s: %v", t.Value, err)
+			t.result = ResultPermerror
+			return
+		}
+	}
+	t.Target = target
+	addrType := dns.TypeA
+	if e.ip != nil && e.ip.To4() == nil {
+		addrType = dns.TypeAAAA
+	}
+	fail := func(err error) {
+		t.Error = e.errorf(domain, "%v", err)
+		t.result = ResultTemperror
+	}
+	switch {
+	case t.Mechanism == "include" || t.Modifier == "redirect":
+		t.Record = e.record(target, path)
+		if t.Record.result == ResultNone && t.Record.Error == "no SPF record" {
+			// whether or not there are other TXT records
+			if _, void, err := e.lookup(target, dns.TypeTXT, true); err == nil && void {
+				t.void = true
+			}
+		}
+	case t.Mechanism == "a":
+		answers, void, err := e.lookup(target, addrType, true)
+		if err != nil {
+			fail(err)
+			return
+		}
+		t.void = void
+		t.addrs = addresses(answers)
+	case t.Mechanism == "mx":
+		answers, void, err := e.lookup(target, dns.TypeMX, true)
+		if err != nil {
+			fail(err)
+			return
+		}
+		t.void = void
+		if len(answers) > maxNames {
+			t.Error = e.errorf(domain, "%s: %d MX records, more than the limit of %d", t.Value, len(answers), maxNames)
+			t.result = ResultPermerror
+			return
+		}
+		if e.ip == nil {
+			return
+		}
+		for _, a := range answers {
+			addrs, _, err := e.lookup(strings.TrimSuffix(answerString(a), "."), addrType, false)
+			if err != nil {
+				fail(err)
+				return
+			}
+			t.addrs = append(t.addrs, addresses(addrs)...)
+		}
+	case t.Mechanism == "ptr":
+		if e.ip == nil {
+			return
+		}
+		rev, err := dns.ReverseAddr(e.ip.String())
+		if err != nil {
+			return
+		}
+		answers, void, err := e.lookup(strings.TrimSuffix(rev, "."), dns.TypePTR, true)
+		if err != nil {
+			// a failing PTR lookup doesn't match, rather than temperror
+			return
+		}
+		t.void = void
+		for i, a := range answers {
+			if i == maxNames {
+				break
+			}
+			name := strings.TrimSuffix(answerString(a), ".")
+			addrs, _, err := e.lookup(name, addrType, false)
+			if err != nil {
+				continue
+			}
+			for _, addr := range addresses(addrs) {
+				if addr.Equal(e.ip) {
+					t.names = append(t.names, name)
+					break
+				}
+			}
+		}
+	case t.Mechanism == "exists":
+		answers, void, err := e.lookup(target, dns.TypeA, true)
+		if err != nil {
+			fail(err)
+			return
+		}
+		t.void = void
+		t.addrs = addresses(answers)
+	}
+}
+
+func addresses(answers []interface{}) []net.IP {
+	var addrs []net.IP
+	for _, a := range answers {
+		if ip := net.ParseIP(answerString(a)); ip != nil {
+			addrs = append(addrs, ip)
+		}
+	}
+	return addrs
+}
+
+func (e *evaluator) macroContext(domain string) *macroContext {
+	local, senderDomain := e.sender, e.helo
+	if i := strings.LastIndexByte(e.sender, '@'); i >= 0 {
+		local, senderDomain = e.sender[:i], e.sender[i+1:]
+	}
+	return &macroContext{
+		sender:       e.sender,
+		local:        local,
+		senderDomain: senderDomain,
+		domain:       domain,
+		ip:           e.ip,
+		helo:         e.helo,
+	}
+}
+
+// checker runs check_host() (RFC 7208, section 4) over an expanded tree,
+// counting lookups up to the first match like a receiver would
+type checker struct {
+	ip             net.IP
+	lookups, voids int
+}
+
+func (c *checker) check(rec *Record) string {
+	if rec.result != "" {
+		return rec.result
+	}
+	var redirect *Term
+	for i := range rec.Terms {
+		t := &rec.Terms[i]
+		if t.Modifier == "redirect" {
+			redirect = t
+		}
+		if t.Mechanism == "" {
+			continue
+		}
+		if res, ok := c.count(t); !ok {
+			return res
+		}
+		matched, res := c.match(t)
+		if res != "" {
+			return res
+		}
+		if matched {
+			return qualifierResult(t.Qualifier)
+		}
+	}
+	if redirect != nil {
+		if res, ok := c.count(redirect); !ok {
+			return res
+		}
+		res := c.check(redirect.Record)
+		if res == ResultNone {
+			return ResultPermerror
+		}
+		return res
+	}
+	return ResultNeutral
+}
+
+// count counts the lookups of t against the limits
+func (c *checker) count(t *Term) (string, bool) {
+	if !t.lookups() {
+		return "", true
+	}
+	c.lookups++
+	if c.lookups > maxDNSLookups {
+		return ResultPermerror, false
+	}
+	if t.result != "" {
+		return t.result, false
+	}
+	if t.void {
+		c.voids++
+		if c.voids > maxVoidLookups {
+			return ResultPermerror, false
+		}
+	}
+	return "", true
+}
+
+func (c *checker) match(t *Term) (bool, string) {
+	switch t.Mechanism {
+	case "all":
+		return true, ""
+	case "include":
+		switch res := c.check(t.Record); res {
+		case ResultPass:
+			return true, ""
+		case ResultFail, ResultSoftfail, ResultNeutral:
+			return false, ""
+		case ResultTemperror:
+			return false, ResultTemperror
+		default:
+			return false, ResultPermerror
+		}
+	case "a", "mx":
+		for _, addr := range t.addrs {
+			if inNetwork(c.ip, addr, t.cidr4, t.cidr6) {
+				return true, ""
+			}
+		}
+	case "ptr":
+		target := strings.ToLower(t.Target)
+		for _, name := range t.names {
+			name = strings.ToLower(name)
+			if name == target || strings.HasSuffix(name, "."+target) {
+				return true, ""
+			}
+		}
+	case "ip4", "ip6":
+		return t.network.Contains(c.ip), ""
+	case "exists":
+		return len(t.addrs) > 0, ""
+	}
+	return false, ""
+}
+
+func inNetwork(ip, addr net.IP, cidr4, cidr6 int) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		addr4 := addr.To4()
+		return addr4 != nil && addr4.Mask(net.CIDRMask(cidr4, 32)).Equal(ip4.Mask(net.CIDRMask(cidr4, 32)))
+	}
+	if addr.To4() != nil {
+		return false
+	}
+	return addr.Mask(net.CIDRMask(cidr6, 128)).Equal(ip.Mask(net.CIDRMask(cidr6, 128)))
+}
+
+func qualifierResult(q string) string {
+	switch q {
+	case "-":
+		return ResultFail
+	case "~":
+		return ResultSoftfail
+	case "?":
+		return ResultNeutral
+	}
+	return ResultPass
+}
+
+// isSPF tells if a TXT record is an SPF record (RFC 7208, section 4.5)
+func isSPF(txt string) bool {
+	return len(txt) >= 6 && strings.EqualFold(txt[:6], "v=spf1") && (len(txt) == 6 || txt[6] == ' ')
+}
+
+// parseRecord parses the terms of an SPF record (RFC 7208, section 12)
+func parseRecord(text string) ([]Term, error) {
+	var terms []Term
+	seen := make(map[string]bool)
+	for _, s := range strings.Split(text, " ")[1:] {
+		if s == "" {
+			continue
+		}
+		t, err := parseTerm(s)
+		if err != nil {
+			return nil, err
+		}
+		if t.Modifier == "redirect" || t.Modifier == "exp" {
+			if seen[t.Modifier] {
+				return nil, fmt.Errorf("more than one %s modifier", t.Modifier)
+			}
+			seen[t.Modifier] = true
+		}
+		terms = append(terms, t)
+	}
+	return terms, nil
+}
+
+func parseTerm(s string) (Term, error) {
+	var t Term
+	if i := strings.IndexByte(s, '='); i > 0 && isModifierName(s[:i]) {
+		t.Modifier, t.Value = strings.ToLower(s[:i]), s[i+1:]
+		switch t.Modifier {
+		case "redirect":
+			if err := checkDomainSpec(t.Value); err != nil {
+				return t, fmt.Errorf("%s: %v", s, err)
+			}
+			t.domainSpec = t.Value
+		case "exp":
+			if err := checkDomainSpec(t.Value); err != nil {
+				return t, fmt.Errorf("%s: %v", s, err)
+			}
+		default:
+			// unknown modifiers are ignored, but must be well-formed
+			if err := checkMacroString(t.Value, false); err != nil {
+				return t, fmt.Errorf("%s: %v", s, err)
+			}
+		}
+		return t, nil
+	}
+	rest := s
+	if strings.ContainsRune("+-~?", rune(rest[0])) {
+		t.Qualifier, rest = rest[:1], rest[1:]
+	}
+	end := strings.IndexAny(rest, ":/")
+	if end < 0 {
+		end = len(rest)
+	}
+	t.Mechanism, rest = strings.ToLower(rest[:end]), rest[end:]
+	t.Value = strings.TrimPrefix(rest, ":")
+	invalid := func(reason string) (Term, error) {
+		return t, fmt.Errorf("%s: %s", s, reason)
+	}
+	switch t.Mechanism {
+	case "all":
+		if rest != "" {
+			return invalid("all takes no arguments")
+		}
+	case "include", "exists":
+		if !strings.HasPrefix(rest, ":") {
+			return invalid("missing domain")
+		}
+		if err := checkDomainSpec(t.Value); err != nil {
+			return invalid(err.Error())
+		}
+		t.domainSpec = t.Value
+	case "a", "mx", "ptr":
+		spec, cidr := splitCIDR(rest)
+		if strings.HasPrefix(spec, ":") {
+			t.domainSpec = spec[1:]
+			if err := checkDomainSpec(t.domainSpec); err != nil {
+				return invalid(err.Error())
+			}
+		} else if spec != "" {
+			return invalid("invalid domain")
+		}
+		if t.Mechanism == "ptr" && cidr != "" {
+			return invalid("ptr takes no prefix length")
+		}
+		var err error
+		if t.cidr4, t.cidr6, err = parseDualCIDR(cidr); err != nil {
+			return invalid(err.Error())
+		}
+	case "ip4", "ip6":
+		if !strings.HasPrefix(rest, ":") {
+			return invalid("missing network")
+		}
+		addr, bits := t.Value, ""
+		if i := strings.IndexByte(addr, '/'); i >= 0 {
+			addr, bits = addr[:i], addr[i+1:]
+		}
+		ip := net.ParseIP(addr)
+		size := 32
+		if t.Mechanism == "ip6" {
+			size = 128
+		}
+		if ip == nil || (t.Mechanism == "ip4") != (ip.To4() != nil && !strings.Contains(addr, ":")) {
+			return invalid("invalid address")
+		}
+		n := size
+		if bits != "" {
+			var err error
+			if n, err = parseCIDRLength(bits, size); err != nil {
+				return invalid(err.Error())
+			}
+		}
+		if size == 32 {
+			ip = ip.To4()
+		}
+		t.network = &net.IPNet{IP: ip.Mask(net.CIDRMask(n, size)), Mask: net.CIDRMask(n, size)}
+	default:
+		return invalid("unknown mechanism")
+	}
+	return t, nil
+}
+
+func isModifierName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// splitCIDR splits the dual-cidr-length off the end of the arguments of a and
+// mx, leaving slashes in macros alone
+func splitCIDR(s string) (string, string) {
+	inMacro := false
+	for i, r := range s {
+		switch {
+		case r == '{':
+			inMacro = true
+		case r == '}':
+			inMacro = false
+		case r == '/' && !inMacro:
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+func parseDualCIDR(s string) (int, int, error) {
+	cidr4, cidr6 := 32, 128
+	if s == "" {
+		return cidr4, cidr6, nil
+	}
+	v4, v6 := s, ""
+	if i := strings.Index(s, "//"); i >= 0 {
+		v4, v6 = s[:i], s[i+2:]
+		if v6 == "" {
+			return 0, 0, errors.New("invalid prefix length")
+		}
+	}
+	var err error
+	if v4 != "" {
+		if cidr4, err = parseCIDRLength(strings.TrimPrefix(v4, "/"), 32); err != nil || !strings.HasPrefix(v4, "/") {
+			return 0, 0, errors.New("invalid prefix length")
+		}
+	}
+	if v6 != "" {
+		if cidr6, err = parseCIDRLength(v6, 128); err != nil {
+			return 0, 0, err
+		}
+	}
+	return cidr4, cidr6, nil
+}
+
+func parseCIDRLength(s string, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > max || (len(s) > 1 && s[0] == '0') {
+		return 0, errors.New("invalid prefix length")
+	}
+	return n, nil
+}
@@ -0,0 +1,245 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package spf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// errUnknownSender is returned when a macro needs the IP of the sender, and
+// there is none
+var errUnknownSender = errors.New("depends on the sender IP")
+
+// macroContext holds the values of the macro letters (RFC 7208, section 7.3)
+type macroContext struct {
+	sender, local, senderDomain string
+	domain                      string
+	ip                          net.IP
+	helo                        string
+}
+
+func (c *macroContext) value(letter byte) (string, error) {
+	switch letter {
+	case 's':
+		return c.sender, nil
+	case 'l':
+		return c.local, nil
+	case 'o':
+		return c.senderDomain, nil
+	case 'd':
+		return c.domain, nil
+	case 'h':
+		return c.helo, nil
+	case 'i', 'v', 'p':
+		if c.ip == nil {
+			return "", errUnknownSender
+		}
+		switch letter {
+		case 'v':
+			if c.ip.To4() != nil {
+				return "in-addr", nil
+			}
+			return "ip6", nil
+		case 'p':
+			// validating the name of the sender costs lookups, and is
+			// discouraged by the RFC
+			return "unknown", nil
+		}
+		if ip4 := c.ip.To4(); ip4 != nil {
+			return ip4.String(), nil
+		}
+		var nibbles []string
+		for _, b := range c.ip.To16() {
+			nibbles = append(nibbles, strconv.FormatUint(uint64(b>>4), 16), strconv.FormatUint(uint64(b&0xf), 16))
+		}
+		return strings.Join(nibbles, "."), nil
+	}
+	return "", fmt.Errorf("invalid macro letter %q", letter)
+}
+
+// walkMacroString calls literal and macro for the parts of a macro-string, in
+// order
+func walkMacroString(s string, literal func(string), macro func(letter byte, digits int, reverse bool, delimiters string) error) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			literal(s[i : i+1])
+			continue
+		}
+		if i+1 == len(s) {
+			return errors.New("incomplete macro")
+		}
+		i++
+		switch s[i] {
+		case '%':
+			literal("%")
+		case '_':
+			literal(" ")
+		case '-':
+			literal("%20")
+		case '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return errors.New("incomplete macro")
+			}
+			expr := s[i+1 : i+end]
+			i += end
+			if expr == "" {
+				return errors.New("empty macro")
+			}
+			letter, expr := expr[0], expr[1:]
+			j := 0
+			for j < len(expr) && expr[j] >= '0' && expr[j] <= '9' {
+				j++
+			}
+			digits := 0
+			if j > 0 {
+				var err error
+				if digits, err = strconv.Atoi(expr[:j]); err != nil || digits == 0 {
+					return errors.New("invalid macro transformer")
+				}
+			}
+			expr = expr[j:]
+			reverse := false
+			if strings.HasPrefix(expr, "r") || strings.HasPrefix(expr, "R") {
+				reverse, expr = true, expr[1:]
+			}
+			if strings.Trim(expr, ".-+,/_=") != "" {
+				return errors.New("invalid macro delimiter")
+			}
+			if err := macro(letter, digits, reverse, expr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid macro %q", s[i-1:i+1])
+		}
+	}
+	return nil
+}
+
+// checkMacroString checks the syntax of a macro-string. The c, r and t
+// letters are only allowed in explanations.
+func checkMacroString(s string, exp bool) error {
+	return walkMacroString(s, func(string) {}, func(letter byte, _ int, _ bool, _ string) error {
+		switch letter | 0x20 {
+		case 's', 'l', 'o', 'd', 'i', 'p', 'h', 'v':
+			return nil
+		case 'c', 'r', 't':
+			if exp {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid macro letter %q", letter)
+	})
+}
+
+// checkDomainSpec checks the syntax of a domain-spec: a macro-string that
+// ends with a macro or a top-level label
+func checkDomainSpec(s string) error {
+	if s == "" {
+		return errors.New("empty domain")
+	}
+	if err := checkMacroString(s, false); err != nil {
+		return err
+	}
+	if strings.HasSuffix(s, "}") {
+		return nil
+	}
+	labels := strings.Split(strings.TrimSuffix(s, "."), ".")
+	if len(labels) < 2 || !isTopLabel(labels[len(labels)-1]) {
+		return errors.New("invalid domain")
+	}
+	return nil
+}
+
+func isTopLabel(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	alpha := false
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			alpha = true
+		case r >= '0' && r <= '9', r == '-':
+		default:
+			return false
+		}
+	}
+	return alpha
+}
+
+// expandDomain expands the macros of a domain-spec and shortens the result
+// to 253 characters (RFC 7208, section 7.3)
+func expandDomain(spec string, c *macroContext) (string, error) {
+	var b strings.Builder
+	err := walkMacroString(spec, func(s string) { b.WriteString(s) }, func(letter byte, digits int, reverse bool, delimiters string) error {
+		value, err := c.value(letter | 0x20)
+		if err != nil {
+			return err
+		}
+		if delimiters == "" {
+			delimiters = "."
+		}
+		parts := strings.FieldsFunc(value, func(r rune) bool { return strings.ContainsRune(delimiters, r) })
+		if reverse {
+			for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+				parts[i], parts[j] = parts[j], parts[i]
+			}
+		}
+		if digits > 0 && digits < len(parts) {
+			parts = parts[len(parts)-digits:]
+		}
+		value = strings.Join(parts, ".")
+		if letter >= 'A' && letter <= 'Z' {
+			value = urlEscape(value)
+		}
+		b.WriteString(value)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	domain := strings.TrimSuffix(b.String(), ".")
+	for len(domain) > 253 {
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 {
+			return "", fmt.Errorf("invalid domain %q", domain)
+		}
+	}
+	return domain, nil
+}
+
+func urlEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
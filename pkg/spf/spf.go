@@ -15,10 +15,14 @@
 package spf
 
 import (
+	"net"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
 	"github.com/zmap/dns"
 	"github.com/zmap/zdns/pkg/miekg"
 	"github.com/zmap/zdns/pkg/zdns"
-	"regexp"
 )
 
 const spfPrefixRegexp = "(?i)^v=spf1"
@@ -26,6 +30,8 @@ const spfPrefixRegexp = "(?i)^v=spf1"
 // result to be returned by scan of host
 type Result struct {
 	Spf string `json:"spf,omitempty" groups:"short,normal,long,trace"`
+	// the evaluation of the policy, with --spf-evaluate
+	Evaluation *Evaluation `json:"evaluation,omitempty" groups:"short,normal,long,trace"`
 }
 
 // Per Connection Lookup ======================================================
@@ -39,9 +45,35 @@ func (s *Lookup) DoLookup(name string, nameServer string) (interface{}, zdns.Tra
 	innerRes, trace, status, err := s.DoMiekgLookup(miekg.Question{Name: name, Type: s.DNSType, Class: s.DNSClass}, nameServer)
 	resString, resStatus, err := s.CheckTxtRecords(innerRes, status, err)
 	res := Result{Spf: resString}
+	// without any record, check_host() gives none
+	if s.Factory.Factory.Evaluate && (status == zdns.STATUS_NOERROR || status == zdns.STATUS_NXDOMAIN || status == zdns.STATUS_NO_ANSWER) {
+		var evalTrace zdns.Trace
+		res.Evaluation, evalTrace = s.Evaluate(name, nameServer, innerRes, status)
+		trace = append(trace, evalTrace...)
+	}
 	return res, trace, resStatus, err
 }
 
+// Evaluate expands the SPF policy of name, given the result of its TXT
+// lookup, and computes the result of check_host() for --spf-ip
+func (s *Lookup) Evaluate(name, nameServer string, txt interface{}, status zdns.Status) (*Evaluation, zdns.Trace) {
+	var trace zdns.Trace
+	query := func(qname string, qtype uint16) ([]interface{}, zdns.Status, error) {
+		res, t, status, err := s.DoMiekgLookup(miekg.Question{Name: qname, Type: qtype, Class: dns.ClassINET}, nameServer)
+		trace = append(trace, t...)
+		return answers(res), status, err
+	}
+	f := s.Factory.Factory
+	e := newEvaluator(query, f.SenderIP, f.Sender, name)
+	e.seed(name, dns.TypeTXT, answers(txt), status)
+	return e.evaluate(name), trace
+}
+
+func answers(res interface{}) []interface{} {
+	r, _ := res.(miekg.Result)
+	return r.Answers
+}
+
 // Per GoRoutine Factory ======================================================
 //
 type RoutineLookupFactory struct {
@@ -64,6 +96,34 @@ func (rlf *RoutineLookupFactory) InitPrefixRegexp() {
 //
 type GlobalLookupFactory struct {
 	miekg.GlobalLookupFactory
+	Evaluate bool
+	SenderIP net.IP
+	Sender   string
+}
+
+func (s *GlobalLookupFactory) SetFlags(f *pflag.FlagSet) {
+	s.GlobalLookupFactory.SetFlags(f)
+	// If there's an error, panic is appropriate since we should at least be getting the default here.
+	var err error
+	s.Evaluate, err = f.GetBool("spf-evaluate")
+	if err != nil {
+		panic(err)
+	}
+	ip, err := f.GetString("spf-ip")
+	if err != nil {
+		panic(err)
+	}
+	if ip != "" {
+		s.SenderIP = net.ParseIP(ip)
+		if s.SenderIP == nil {
+			log.Fatal("Invalid --spf-ip: ", ip)
+		}
+		s.Evaluate = true
+	}
+	s.Sender, err = f.GetString("spf-sender")
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (s *GlobalLookupFactory) MakeRoutineFactory(threadID int) (zdns.RoutineLookupFactory, error) {
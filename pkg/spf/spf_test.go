@@ -1,6 +1,9 @@
 package spf
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/zmap/dns"
 	"github.com/zmap/zdns/pkg/miekg"
 	"github.com/zmap/zdns/pkg/zdns"
@@ -124,3 +127,191 @@ func TestLookup_DoTxtLookup_NoTXT(t *testing.T) {
 	assert.Equal(t, zdns.STATUS_NO_ANSWER, status)
 	assert.Equal(t, res.(Result).Spf, "")
 }
+
+func txt(name string, records ...string) miekg.Result {
+	var res miekg.Result
+	for _, r := range records {
+		res.Answers = append(res.Answers, miekg.Answer{Name: name, Type: "TXT", Answer: r})
+	}
+	return res
+}
+
+func evaluate(t *testing.T, glf *GlobalLookupFactory, l zdns.Lookup, name, ip string) *Evaluation {
+	glf.Evaluate = true
+	glf.SenderIP = net.ParseIP(ip)
+	res, _, _, _ := l.DoLookup(name, "")
+	assert.Assert(t, res.(Result).Evaluation != nil)
+	return res.(Result).Evaluation
+}
+
+func TestEvaluate(t *testing.T) {
+	_, glf, _, l := InitTest()
+	mockResults["example.com"] = txt("example.com", "v=spf1 ip4:192.0.2.0/24 mx include:_spf.example.net -all")
+	mockResults["example.com"] = miekg.Result{Answers: append(mockResults["example.com"].Answers,
+		miekg.PrefAnswer{Answer: miekg.Answer{Name: "example.com", Type: "MX", Answer: "mx.example.com."}, Preference: 10})}
+	mockResults["mx.example.com"] = miekg.Result{Answers: []interface{}{miekg.Answer{Name: "mx.example.com", Type: "A", Answer: "203.0.113.25"}}}
+	mockResults["_spf.example.net"] = txt("_spf.example.net", "v=spf1 a:mail.example.net ~all")
+	mockResults["mail.example.net"] = miekg.Result{Answers: []interface{}{miekg.Answer{Name: "mail.example.net", Type: "A", Answer: "198.51.100.5"}}}
+
+	for ip, result := range map[string]string{
+		"192.0.2.10":   ResultPass,
+		"203.0.113.25": ResultPass,
+		"198.51.100.5": ResultPass,
+		"198.51.100.6": ResultFail,
+		"2001:db8::1":  ResultFail,
+	} {
+		ev := evaluate(t, glf, l, "example.com", ip)
+		assert.Equal(t, ev.Result, result, ip)
+		assert.Equal(t, ev.DNSLookups, 3)
+		assert.Equal(t, len(ev.Errors), 0)
+	}
+
+	ev := evaluate(t, glf, l, "example.com", "")
+	assert.Equal(t, ev.Result, "")
+	assert.Equal(t, ev.Record.Text, "v=spf1 ip4:192.0.2.0/24 mx include:_spf.example.net -all")
+	assert.Equal(t, len(ev.Record.Terms), 4)
+	assert.Equal(t, ev.Record.Terms[2].Mechanism, "include")
+	assert.Equal(t, ev.Record.Terms[2].Record.Terms[0].Target, "mail.example.net")
+	assert.Equal(t, ev.Record.Terms[3].Qualifier, "-")
+}
+
+func TestEvaluate_LookupLimit(t *testing.T) {
+	_, glf, _, l := InitTest()
+	record := "v=spf1"
+	for i := 0; i < 11; i++ {
+		name := fmt.Sprintf("i%d.example.com", i)
+		record += " include:" + name
+		mockResults[name] = txt(name, "v=spf1 -all")
+	}
+	mockResults["example.com"] = txt("example.com", record+" ?all")
+	ev := evaluate(t, glf, l, "example.com", "192.0.2.1")
+	assert.Equal(t, ev.DNSLookups, 11)
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.DeepEqual(t, ev.Errors, []string{"11 DNS lookups, more than the limit of 10"})
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	_, glf, _, l := InitTest()
+	mockResults["multiple.example"] = txt("multiple.example", "v=spf1 -all", "v=spf1 ~all")
+	mockResults["syntax.example"] = txt("syntax.example", "v=spf1 ip4:192.0.2.0/33 -all")
+	mockResults["void.example"] = txt("void.example", "v=spf1 a:a.nx.example a:b.nx.example a:c.nx.example -all")
+	mockResults["missing.example"] = txt("missing.example", "v=spf1 include:nx.example -all")
+	mockResults["loop.example"] = txt("loop.example", "v=spf1 redirect=loop.example")
+
+	ev := evaluate(t, glf, l, "multiple.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.DeepEqual(t, ev.Errors, []string{"multiple.example: 2 SPF records"})
+
+	ev = evaluate(t, glf, l, "syntax.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.DeepEqual(t, ev.Errors, []string{"syntax.example: ip4:192.0.2.0/33: invalid prefix length"})
+
+	ev = evaluate(t, glf, l, "void.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.Equal(t, ev.VoidLookups, 3)
+	assert.DeepEqual(t, ev.Errors, []string{"3 void lookups, more than the limit of 2"})
+
+	ev = evaluate(t, glf, l, "missing.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.DeepEqual(t, ev.Errors, []string{"nx.example: no SPF record"})
+
+	ev = evaluate(t, glf, l, "loop.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.DeepEqual(t, ev.Errors, []string{"loop.example: include loop"})
+
+	ev = evaluate(t, glf, l, "none.example", "192.0.2.1")
+	assert.Equal(t, ev.Result, ResultNone)
+	assert.Equal(t, len(ev.Errors), 0)
+}
+
+func TestParseRecord(t *testing.T) {
+	terms, err := parseRecord("v=spf1 +a mx/24//64 ?ptr:example.com ip6:2001:db8::/32 exists:%{i}._spf.%{d} exp=explain.%{d} foo=bar ~all")
+	assert.NilError(t, err)
+	assert.Equal(t, len(terms), 8)
+	assert.Equal(t, terms[1].cidr4, 24)
+	assert.Equal(t, terms[1].cidr6, 64)
+	assert.Equal(t, terms[3].network.String(), "2001:db8::/32")
+	assert.Equal(t, terms[6].Modifier, "foo")
+
+	for _, record := range []string{
+		"v=spf1 all:example.com",
+		"v=spf1 a:localhost",
+		"v=spf1 ip4:2001:db8::1",
+		"v=spf1 include",
+		"v=spf1 redirect=a.example redirect=b.example",
+		"v=spf1 exists:%{x}.example.com",
+		"v=spf1 a/024",
+		"v=spf1 foo:example.com",
+	} {
+		_, err := parseRecord(record)
+		assert.Assert(t, err != nil, record)
+	}
+}
+
+func TestExpandDomain(t *testing.T) {
+	c := &macroContext{
+		sender:       "strong-bad@email.example.com",
+		local:        "strong-bad",
+		senderDomain: "email.example.com",
+		domain:       "email.example.com",
+		ip:           net.ParseIP("192.0.2.3"),
+		helo:         "email.example.com",
+	}
+	// examples of RFC 7208, section 7.4
+	for spec, expanded := range map[string]string{
+		"%{s}":                              "strong-bad@email.example.com",
+		"%{o}":                              "email.example.com",
+		"%{d4}":                             "email.example.com",
+		"%{d2}":                             "example.com",
+		"%{d1}":                             "com",
+		"%{dr}":                             "com.example.email",
+		"%{d2r}":                            "example.email",
+		"%{l-}":                             "strong.bad",
+		"%{lr-}":                            "bad.strong",
+		"%{l1r-}":                           "strong",
+		"%{ir}.%{v}._spf.%{d2}":             "3.2.0.192.in-addr._spf.example.com",
+		"%{lr-}.lp._spf.%{d2}":              "bad.strong.lp._spf.example.com",
+		"%{d2}.trusted-domains.example.net": "example.com.trusted-domains.example.net",
+	} {
+		got, err := expandDomain(spec, c)
+		assert.NilError(t, err, spec)
+		assert.Equal(t, got, expanded, spec)
+	}
+	c.ip = net.ParseIP("2001:db8::cb01")
+	got, err := expandDomain("%{ir}.%{v}._spf.%{d2}", c)
+	assert.NilError(t, err)
+	assert.Equal(t, got, "1.0.b.c.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6._spf.example.com")
+
+	c.ip = nil
+	_, err = expandDomain("%{i}.example.com", c)
+	assert.Equal(t, err, errUnknownSender)
+}
+
+func TestEvaluate_IncludeFanOut(t *testing.T) {
+	// each record includes the next one 8 times, so that the tree has 8^9
+	// include terms
+	records := make(map[string]string)
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("n%d.example.com", i)
+		record := "v=spf1"
+		if i < 9 {
+			for j := 0; j < 8; j++ {
+				record += fmt.Sprintf(" include:n%d.example.com", i+1)
+			}
+		}
+		records[name] = record + " -all"
+	}
+	queries := 0
+	ev := EvaluateDomain(func(name string, qtype uint16) ([]interface{}, zdns.Status, error) {
+		queries++
+		if r, ok := records[name]; ok {
+			return []interface{}{miekg.Answer{Name: name, Type: "TXT", Answer: r}}, zdns.STATUS_NOERROR, nil
+		}
+		return nil, zdns.STATUS_NO_ANSWER, nil
+	}, "n0.example.com", net.ParseIP("192.0.2.1"), "")
+	assert.Equal(t, ev.Result, ResultPermerror)
+	assert.Assert(t, ev.DNSLookups > maxDNSLookups, ev.DNSLookups)
+	assert.Assert(t, queries <= 10, queries)
+	assert.Assert(t, len(ev.Errors) == 2, ev.Errors)
+	assert.Equal(t, ev.Errors[0], fmt.Sprintf("n8.example.com: stopped expanding the policy after %d DNS lookups", maxExpandedLookups))
+}
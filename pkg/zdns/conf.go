@@ -17,6 +17,8 @@ package zdns
 import (
 	"net"
 	"time"
+
+	"github.com/spf13/pflag"
 )
 
 type GlobalConf struct {
@@ -50,16 +52,48 @@ type GlobalConf struct {
 	OutputHandler OutputHandler
 
 	InputFilePath    string
+	InputFormat      string
+	ExpandRanges     bool
+	PermuteRanges    bool
+	PermutationSeed  int64
 	OutputFilePath   string
+	OutputFormat     string
 	LogFilePath      string
 	MetadataFilePath string
 
+	// compression of the input, and of the output and metadata files
+	InputCompression  string
+	OutputCompression string
+
+	// rolling --output-file over to new files, and sharding it by name
+	OutputRotateRecords  int
+	OutputRotateBytes    int64
+	OutputRotateInterval time.Duration
+	OutputShards         int
+
+	StatusUpdatesFilePath string
+	StatusUpdatesInterval time.Duration
+
+	MetricsAddr string
+	HTTPAddr    string
+	GRPCAddr    string
+
 	NamePrefix     string
 	NameOverride   string
 	NameServerMode bool
+	ModulePerLine  bool
 
 	Module string
-	Class  uint16
+	// modules to run over every input line, from --modules
+	Modules []string
+	Class   uint16
+
+	// state shared by all modules in this process
+	Shared *SharedState `json:"-"`
+
+	// command line flags, used to set up the factories of modules that are
+	// selected by individual input lines
+	flags *pflag.FlagSet
 }
 
 type Metadata struct {
@@ -79,12 +113,14 @@ type Result struct {
 	Nameserver  string        `json:"nameserver,omitempty" groups:"normal,long,trace"`
 	Class       string        `json:"class,omitempty" groups:"long,trace"`
 	AlexaRank   int           `json:"alexa_rank,omitempty" groups:"short,normal,long,trace"`
-	Metadata    string        `json:"metadata,omitempty" groups:"short,normal,long,trace"`
+	Metadata    interface{}   `json:"metadata,omitempty" groups:"short,normal,long,trace"`
 	Status      string        `json:"status,omitempty" groups:"short,normal,long,trace"`
 	Error       string        `json:"error,omitempty" groups:"short,normal,long,trace"`
 	Timestamp   string        `json:"timestamp,omitempty" groups:"short,normal,long,trace"`
 	Data        interface{}   `json:"data,omitempty" groups:"short,normal,long,trace"`
 	Trace       []interface{} `json:"trace,omitempty" groups:"trace"`
+	// one result per module when running with --modules
+	Results map[string]Result `json:"results,omitempty" groups:"short,normal,long,trace"`
 }
 
 type TargetedDomain struct {
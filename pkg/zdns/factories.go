@@ -0,0 +1,156 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+var ErrUnknownModule = errors.New("unknown lookup module")
+var errNoModuleFlags = errors.New("lookup module can't be initialized without command line flags")
+
+// SharedState holds values that are shared by every module running in the
+// same process, such as the iterative cache
+type SharedState struct {
+	sync.Mutex
+	values map[string]interface{}
+}
+
+func NewSharedState() *SharedState {
+	return &SharedState{values: make(map[string]interface{})}
+}
+
+// Get returns the value stored under key, storing the result of create
+// there first if there isn't one
+func (s *SharedState) Get(key string, create func() interface{}) interface{} {
+	s.Lock()
+	defer s.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		v = create()
+		s.values[key] = v
+	}
+	return v
+}
+
+// factorySet initializes the global factory of each lookup module the first
+// time it is needed. This lets a single process serve lookups for any
+// registered module while only paying for the modules that are actually used.
+// Each module gets its own copy of the GlobalConf, since some modules adjust
+// it during initialization (e.g., BINDVERSION sets the class). State that
+// modules should share goes in GlobalConf.Shared instead.
+type factorySet struct {
+	sync.Mutex
+	gc        *GlobalConf
+	flags     *pflag.FlagSet
+	factories map[string]GlobalLookupFactory
+	// modules whose factory was initialized by the set, rather than added
+	owned map[string]bool
+}
+
+func newFactorySet(gc *GlobalConf) *factorySet {
+	return &factorySet{
+		gc:        gc,
+		flags:     gc.flags,
+		factories: make(map[string]GlobalLookupFactory),
+		owned:     make(map[string]bool),
+	}
+}
+
+// add registers an already initialized factory for module. It is up to the
+// caller to finalize it.
+func (s *factorySet) add(module string, f GlobalLookupFactory) {
+	s.Lock()
+	defer s.Unlock()
+	s.factories[strings.ToUpper(module)] = f
+}
+
+// get returns the initialized global factory for module
+func (s *factorySet) get(module string) (GlobalLookupFactory, error) {
+	module = strings.ToUpper(module)
+	s.Lock()
+	defer s.Unlock()
+	if f, ok := s.factories[module]; ok {
+		return f, nil
+	}
+	f := GetLookup(module)
+	if f == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModule, module)
+	}
+	if s.flags == nil {
+		return nil, errNoModuleFlags
+	}
+	f.SetFlags(s.flags)
+	conf := *s.gc
+	conf.Module = module
+	if err := f.Initialize(&conf); err != nil {
+		return nil, err
+	}
+	s.factories[module] = f
+	s.owned[module] = true
+	return f, nil
+}
+
+// finalize finalizes every factory that was initialized by the set
+func (s *factorySet) finalize() {
+	s.Lock()
+	defer s.Unlock()
+	for module := range s.owned {
+		f := s.factories[module]
+		if err := f.Finalize(); err != nil {
+			log.Error("Factory for ", module, " was unable to finalize: ", err.Error())
+		}
+	}
+}
+
+// routineFactories holds one routine factory per module for a single worker
+// routine, creating them from a factorySet on first use.
+type routineFactories struct {
+	set       *factorySet
+	threadID  int
+	factories map[string]RoutineLookupFactory
+}
+
+func newRoutineFactories(set *factorySet, threadID int) *routineFactories {
+	return &routineFactories{
+		set:       set,
+		threadID:  threadID,
+		factories: make(map[string]RoutineLookupFactory),
+	}
+}
+
+// get returns this routine's factory for module
+func (r *routineFactories) get(module string) (RoutineLookupFactory, error) {
+	module = strings.ToUpper(module)
+	if f, ok := r.factories[module]; ok {
+		return f, nil
+	}
+	g, err := r.set.get(module)
+	if err != nil {
+		return nil, err
+	}
+	f, err := g.MakeRoutineFactory(r.threadID)
+	if err != nil {
+		return nil, err
+	}
+	r.factories[module] = f
+	return f, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+
+	"github.com/zmap/dns"
+	"github.com/zmap/zdns/internal/util"
+)
+
+// lookupInput is a single parsed line of input
+type lookupInput struct {
+	Name       string
+	NameServer string
+	// module to use for this line. Empty for the module given on the command line
+	Module    string
+	Options   QueryOptions
+	AlexaRank int
+	Metadata  interface{}
+}
+
+// jsonInput is a single line of --input-format=jsonl input
+type jsonInput struct {
+	Name         string      `json:"name"`
+	NameServers  []string    `json:"nameservers"`
+	Module       string      `json:"module"`
+	Type         string      `json:"type"`
+	Class        string      `json:"class"`
+	Metadata     interface{} `json:"metadata"`
+	ClientSubnet string      `json:"client_subnet"`
+	DNSSEC       bool        `json:"dnssec"`
+}
+
+// parseClientSubnet accepts either a CIDR block or a single address, which
+// is treated as a full-length prefix
+func parseClientSubnet(s string) (*net.IPNet, error) {
+	if _, subnet, err := net.ParseCIDR(s); err == nil {
+		return subnet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid client subnet: %s", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+func parseJSONInputLine(line string) (lookupInput, error) {
+	var in lookupInput
+	var j jsonInput
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return in, fmt.Errorf("invalid JSON input: %s", err.Error())
+	}
+	in.Name = j.Name
+	in.Metadata = j.Metadata
+	if len(j.NameServers) > 0 {
+		in.NameServer = util.AddDefaultPortToDNSServerName(j.NameServers[rand.Intn(len(j.NameServers))])
+	}
+	in.Module = strings.ToUpper(j.Module)
+	if j.Type != "" {
+		// each query type is looked up by the raw module of the same name
+		qtype := strings.ToUpper(j.Type)
+		if _, ok := dns.StringToType[qtype]; !ok {
+			return in, fmt.Errorf("unknown query type: %s", j.Type)
+		}
+		if in.Module != "" && in.Module != qtype {
+			return in, errors.New("type can only be combined with the module of the same name")
+		}
+		in.Module = qtype
+	}
+	if j.Class != "" {
+		class, ok := parseClass(j.Class)
+		if !ok {
+			return in, fmt.Errorf("unknown class: %s", j.Class)
+		}
+		in.Options.Class = class
+	}
+	if j.ClientSubnet != "" {
+		subnet, err := parseClientSubnet(j.ClientSubnet)
+		if err != nil {
+			return in, err
+		}
+		in.Options.ClientSubnet = subnet
+	}
+	in.Options.DNSSEC = j.DNSSEC
+	return in, nil
+}
+
+// parseModuleInputLine parses a line of the form name,MODULE[,nameserver]
+func parseModuleInputLine(line string) (string, string, string) {
+	s := strings.SplitN(line, ",", 3)
+	switch len(s) {
+	case 1:
+		return s[0], "", ""
+	case 2:
+		return s[0], strings.ToUpper(strings.TrimSpace(s[1])), ""
+	default:
+		return s[0], strings.ToUpper(strings.TrimSpace(s[1])), util.AddDefaultPortToDNSServerName(s[2])
+	}
+}
+
+// parseInputLine parses a line of input according to the configured input format
+func parseInputLine(gc *GlobalConf, line string) (lookupInput, error) {
+	var in lookupInput
+	if gc.InputFormat == "jsonl" {
+		return parseJSONInputLine(line)
+	}
+	if gc.AlexaFormat == true {
+		in.Name, in.AlexaRank = parseAlexa(line)
+	} else if gc.MetadataFormat {
+		var metadata string
+		in.Name, metadata = parseMetadataInputLine(line)
+		if metadata != "" {
+			in.Metadata = metadata
+		}
+	} else if gc.NameServerMode {
+		in.NameServer = util.AddDefaultPortToDNSServerName(line)
+	} else if gc.ModulePerLine {
+		in.Name, in.Module, in.NameServer = parseModuleInputLine(line)
+	} else if p, ok := GetLookup(gc.Module).(LineParser); ok && len(gc.Modules) == 0 {
+		in.Name, in.NameServer = p.ParseLine(line)
+	} else {
+		in.Name, in.NameServer = parseNormalInputLine(line)
+	}
+	return in, nil
+}
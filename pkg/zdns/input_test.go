@@ -0,0 +1,77 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"testing"
+
+	"github.com/zmap/dns"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseJSONInputLine(t *testing.T) {
+	in, err := parseJSONInputLine(`{"name": "a,b.com", "nameservers": ["1.1.1.1"], "type": "mx", "class": "CH", "metadata": {"id": 1}, "client_subnet": "192.0.2.1/24", "dnssec": true}`)
+	assert.NilError(t, err)
+	assert.Equal(t, in.Name, "a,b.com")
+	assert.Equal(t, in.NameServer, "1.1.1.1:53")
+	assert.Equal(t, in.Module, "MX")
+	assert.Equal(t, in.Options.Class, uint16(dns.ClassCHAOS))
+	assert.Equal(t, in.Options.ClientSubnet.String(), "192.0.2.0/24")
+	assert.Assert(t, in.Options.DNSSEC)
+	assert.DeepEqual(t, in.Metadata, map[string]interface{}{"id": float64(1)})
+}
+
+func TestParseJSONInputLineDefaults(t *testing.T) {
+	in, err := parseJSONInputLine(`{"name": "example.com", "module": "mxlookup", "client_subnet": "2001:db8::1"}`)
+	assert.NilError(t, err)
+	assert.Equal(t, in.NameServer, "")
+	assert.Equal(t, in.Module, "MXLOOKUP")
+	assert.Equal(t, in.Options.Class, uint16(0))
+	assert.Equal(t, in.Options.ClientSubnet.String(), "2001:db8::1/128")
+	assert.Equal(t, in.Metadata, nil)
+}
+
+func TestParseJSONInputLineErrors(t *testing.T) {
+	for _, line := range []string{
+		`example.com`,
+		`{"name": "example.com", "nameserver": "1.1.1.1"}`,
+		`{"name": "example.com", "type": "BOGUS"}`,
+		`{"name": "example.com", "type": "MX", "module": "A"}`,
+		`{"name": "example.com", "class": "BOGUS"}`,
+		`{"name": "example.com", "client_subnet": "bogus"}`,
+	} {
+		_, err := parseJSONInputLine(line)
+		assert.Assert(t, err != nil, line)
+	}
+}
+
+func TestParseModuleInputLine(t *testing.T) {
+	gc := &GlobalConf{ModulePerLine: true}
+	in, err := parseInputLine(gc, "example.com, mx")
+	assert.NilError(t, err)
+	assert.Equal(t, in.Name, "example.com")
+	assert.Equal(t, in.Module, "MX")
+	assert.Equal(t, in.NameServer, "")
+
+	in, err = parseInputLine(gc, "192.0.2.1,PTR,8.8.8.8")
+	assert.NilError(t, err)
+	assert.Equal(t, in.Name, "192.0.2.1")
+	assert.Equal(t, in.Module, "PTR")
+	assert.Equal(t, in.NameServer, "8.8.8.8:53")
+
+	in, err = parseInputLine(gc, "example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, in.Module, "")
+}
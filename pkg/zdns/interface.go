@@ -60,6 +60,27 @@ func (base *BaseLookup) DoLookup(name string, class uint16) (interface{}, Status
 	return nil, STATUS_ERROR, nil
 }
 
+// QueryOptions are per-query settings that can be given in structured input
+type QueryOptions struct {
+	// DNS class to query instead of --class. Zero means unset
+	Class uint16
+	// EDNS Client Subnet to send with each query
+	ClientSubnet *net.IPNet
+	// set the DNSSEC OK (DO) bit
+	DNSSEC bool
+}
+
+func (o QueryOptions) IsZero() bool {
+	return o.Class == 0 && o.ClientSubnet == nil && !o.DNSSEC
+}
+
+// Lookups that can honor QueryOptions implement OptionsLookup. The framework
+// calls SetQueryOptions before DoLookup whenever an input line has options.
+type OptionsLookup interface {
+	Lookup
+	SetQueryOptions(opts QueryOptions) error
+}
+
 // one RoutineLookupFactory per goroutine =====================================
 //
 type RoutineLookupFactory interface {
@@ -88,12 +109,30 @@ type GlobalLookupFactory interface {
 	RandomNameServer() string
 }
 
+// global factories of modules whose lines of text input carry more than a
+// name and a name server (e.g., DKIM's domain,selector) can implement this
+// to parse them
+type LineParser interface {
+	// ParseLine returns the name to look up and the name server, if any,
+	// given on a line of input
+	ParseLine(line string) (name string, nameServer string)
+}
+
 // handle domain input
 type InputHandler interface {
 	// FeedChannel takes a channel to write domains to, the WaitGroup managing them, and if it's a zonefile input
 	FeedChannel(in chan<- interface{}, wg *sync.WaitGroup) error
 }
 
+// input handlers that know how much input they have consumed, and how much
+// there is in total, can implement this to allow status updates to report an ETA
+type ProgressInputHandler interface {
+	InputHandler
+	// Progress returns the number of bytes read so far and the total input size
+	// in bytes. A total of zero means that the size is unknown.
+	Progress() (read int64, total int64)
+}
+
 // handle output results
 type OutputHandler interface {
 	// takes a channel (results) to write the query results to, and the WaitGroup managing the handlers
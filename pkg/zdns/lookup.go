@@ -16,7 +16,7 @@ package zdns
 
 import (
 	"encoding/json"
-	"os"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,12 +26,20 @@ import (
 	"github.com/liip/sheriff"
 	log "github.com/sirupsen/logrus"
 	"github.com/zmap/dns"
+	"github.com/zmap/zdns/internal/metrics"
 	"github.com/zmap/zdns/internal/util"
+	"github.com/zmap/zdns/iohandlers"
 )
 
 type routineMetadata struct {
-	Names  int
-	Status map[Status]int
+	sync.Mutex
+	Names    int
+	InFlight int
+	Status   map[Status]int
+}
+
+func newRoutineMetadata() *routineMetadata {
+	return &routineMetadata{Status: make(map[Status]int)}
 }
 
 func GetDNSServers(path string) ([]string, error) {
@@ -88,84 +96,174 @@ func makeName(name, prefix, nameOverride string) (string, bool) {
 	}
 }
 
-func doLookup(g GlobalLookupFactory, gc *GlobalConf, input <-chan interface{}, output chan<- string, metaChan chan<- routineMetadata, wg *sync.WaitGroup, threadID int) error {
-	f, err := g.MakeRoutineFactory(threadID)
+// runLookup looks up rawName using l and fills in the rest of res, keeping
+// the class if it was already set. The
+// lookup's status is returned even if the module asked for no output, in
+// which case res is left without status or data.
+func runLookup(l Lookup, gc *GlobalConf, module, rawName, nameServer string, res *Result) Status {
+	lookupName, changed := makeName(rawName, gc.NamePrefix, gc.NameOverride)
+	if changed {
+		res.AlteredName = lookupName
+	}
+	res.Name = rawName
+	if res.Class == "" {
+		res.Class = dns.Class(gc.Class).String()
+	}
+	metrics.LookupsInFlight.Inc()
+	innerRes, trace, status, err := l.DoLookup(lookupName, nameServer)
+	metrics.LookupsInFlight.Dec()
+	metrics.Lookups.WithLabelValues(module, string(status)).Inc()
+	res.Timestamp = time.Now().Format(gc.TimeFormat)
+	if status != STATUS_NO_OUTPUT {
+		res.Status = string(status)
+		res.Data = innerRes
+		res.Trace = trace
+		if err != nil {
+			res.Error = err.Error()
+		}
+	}
+	return status
+}
+
+// filterResult drops the fields of res that aren't part of the configured
+// output groups. The returned value is ready to be marshaled to JSON.
+func filterResult(gc *GlobalConf, res Result) (interface{}, error) {
+	v, _ := version.NewVersion("0.0.0")
+	o := &sheriff.Options{
+		Groups:     gc.OutputGroups,
+		ApiVersion: v,
+	}
+	return sheriff.Marshal(o, res)
+}
+
+// prepareLookup creates a lookup using module and applies opts to it
+func prepareLookup(factories *routineFactories, module string, opts QueryOptions) (Lookup, error) {
+	f, err := factories.get(module)
+	if err != nil {
+		return nil, err
+	}
+	l, err := f.MakeLookup()
+	if err != nil {
+		log.Fatal("Unable to build lookup instance", err)
+	}
+	if !opts.IsZero() {
+		ol, ok := l.(OptionsLookup)
+		if !ok {
+			return nil, fmt.Errorf("module %s does not support query options", module)
+		}
+		if err := ol.SetQueryOptions(opts); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// illegalInput fills in res for input that couldn't be looked up
+func illegalInput(gc *GlobalConf, res *Result, err error) Status {
+	res.Status = string(STATUS_ILLEGAL_INPUT)
+	res.Error = err.Error()
+	res.Timestamp = time.Now().Format(gc.TimeFormat)
+	return STATUS_ILLEGAL_INPUT
+}
+
+// lookupModule looks up in using module and fills in res
+func lookupModule(factories *routineFactories, gc *GlobalConf, module string, in lookupInput, res *Result) Status {
+	if in.Options.Class != 0 {
+		res.Class = dns.Class(in.Options.Class).String()
+	}
+	l, err := prepareLookup(factories, module, in.Options)
 	if err != nil {
+		res.Name = in.Name
+		return illegalInput(gc, res, err)
+	}
+	return runLookup(l, gc, module, in.Name, in.NameServer, res)
+}
+
+// lookupModules looks up in using every module given with --modules and
+// combines the results into res, with a section per module. Modules that
+// produce no output are left out.
+func lookupModules(factories *routineFactories, gc *GlobalConf, in lookupInput, res *Result) []Status {
+	statuses := make([]Status, 0, len(gc.Modules))
+	res.Name = in.Name
+	res.Results = make(map[string]Result, len(gc.Modules))
+	for _, module := range gc.Modules {
+		var sub Result
+		status := lookupModule(factories, gc, module, in, &sub)
+		statuses = append(statuses, status)
+		if status == STATUS_NO_OUTPUT {
+			continue
+		}
+		// the name is the same for every module
+		if sub.AlteredName != "" {
+			res.AlteredName = sub.AlteredName
+		}
+		sub.Name, sub.AlteredName = "", ""
+		res.Results[module] = sub
+	}
+	res.Timestamp = time.Now().Format(gc.TimeFormat)
+	return statuses
+}
+
+func doLookup(factories *factorySet, gc *GlobalConf, input <-chan interface{}, output chan<- string, metadata *routineMetadata, wg *sync.WaitGroup, threadID int) error {
+	routineFactories := newRoutineFactories(factories, threadID)
+	if _, err := routineFactories.get(gc.Module); err != nil {
 		log.Fatal("Unable to create new routine factory", err.Error())
 	}
-	var metadata routineMetadata
-	metadata.Status = make(map[Status]int)
 	for genericInput := range input {
 		var res Result
-		var innerRes interface{}
-		var trace []interface{}
-		var status Status
-		var err error
-		l, err := f.MakeLookup()
-		if err != nil {
-			log.Fatal("Unable to build lookup instance", err)
-		}
+		var statuses []Status
 		line := genericInput.(string)
-		var changed bool
-		var lookupName string
-		rawName := ""
-		nameServer := ""
-		var rank int
-		var entryMetadata string
-		if gc.AlexaFormat == true {
-			rawName, rank = parseAlexa(line)
-			res.AlexaRank = rank
-		} else if gc.MetadataFormat {
-			rawName, entryMetadata = parseMetadataInputLine(line)
-			res.Metadata = entryMetadata
-		} else if gc.NameServerMode {
-			nameServer = util.AddDefaultPortToDNSServerName(line)
+		metadata.Lock()
+		metadata.InFlight++
+		metadata.Unlock()
+		in, err := parseInputLine(gc, line)
+		res.AlexaRank = in.AlexaRank
+		res.Metadata = in.Metadata
+		if err != nil {
+			res.Name = in.Name
+			statuses = []Status{illegalInput(gc, &res, err)}
+		} else if in.Module == "" && len(gc.Modules) > 0 {
+			statuses = lookupModules(routineFactories, gc, in, &res)
 		} else {
-			rawName, nameServer = parseNormalInputLine(line)
-		}
-		lookupName, changed = makeName(rawName, gc.NamePrefix, gc.NameOverride)
-		if changed {
-			res.AlteredName = lookupName
+			module := in.Module
+			if module == "" {
+				module = gc.Module
+			}
+			statuses = []Status{lookupModule(routineFactories, gc, module, in, &res)}
 		}
-		res.Name = rawName
-		res.Class = dns.Class(gc.Class).String()
-		innerRes, trace, status, err = l.DoLookup(lookupName, nameServer)
-		res.Timestamp = time.Now().Format(gc.TimeFormat)
-		if status != STATUS_NO_OUTPUT {
-			res.Status = string(status)
-			res.Data = innerRes
-			res.Trace = trace
+		if res.Status != "" || len(res.Results) > 0 {
+			data, err := filterResult(gc, res)
 			if err != nil {
-				res.Error = err.Error()
+				log.Fatal("Unable to filter result", err)
 			}
-			v, _ := version.NewVersion("0.0.0")
-			o := &sheriff.Options{
-				Groups:     gc.OutputGroups,
-				ApiVersion: v,
-			}
-			data, err := sheriff.Marshal(o, res)
 			jsonRes, err := json.Marshal(data)
 			if err != nil {
 				log.Fatal("Unable to marshal JSON result", err)
 			}
 			output <- string(jsonRes)
 		}
+		metadata.Lock()
+		metadata.InFlight--
 		metadata.Names++
-		metadata.Status[status]++
+		for _, status := range statuses {
+			metadata.Status[status]++
+		}
+		metadata.Unlock()
 	}
-	metaChan <- metadata
 	wg.Done()
 	return nil
 }
 
-func aggregateMetadata(c <-chan routineMetadata) Metadata {
+func aggregateMetadata(metas []*routineMetadata) Metadata {
 	var meta Metadata
 	meta.Status = make(map[string]int)
-	for m := range c {
+	for _, m := range metas {
+		m.Lock()
 		meta.Names += m.Names
 		for k, v := range m.Status {
 			meta.Status[string(k)] += v
 		}
+		m.Unlock()
 	}
 	return meta
 }
@@ -178,7 +276,7 @@ func DoLookups(g GlobalLookupFactory, c *GlobalConf) error {
 	// output and metadata threads have completed
 	inChan := make(chan interface{})
 	outChan := make(chan string)
-	metaChan := make(chan routineMetadata, c.Threads)
+	metas := make([]*routineMetadata, c.Threads)
 	var routineWG sync.WaitGroup
 
 	inHandler := c.InputHandler
@@ -200,16 +298,29 @@ func DoLookups(g GlobalLookupFactory, c *GlobalConf) error {
 	var lookupWG sync.WaitGroup
 	lookupWG.Add(c.Threads)
 	startTime := time.Now().Format(c.TimeFormat)
+	// modules other than the one given on the command line are only set up if
+	// input lines ask for them
+	factories := newFactorySet(c)
+	factories.add(c.Module, g)
 	for i := 0; i < c.Threads; i++ {
-		go doLookup(g, c, inChan, outChan, metaChan, &lookupWG, i)
+		metas[i] = newRoutineMetadata()
+		go doLookup(factories, c, inChan, outChan, metas[i], &lookupWG, i)
+	}
+	var status *statusHandler
+	if c.StatusUpdatesFilePath != "" {
+		status = newStatusHandler(c, metas, inHandler)
+		go status.run()
 	}
 	lookupWG.Wait()
+	if status != nil {
+		status.stop()
+	}
 	close(outChan)
-	close(metaChan)
 	routineWG.Wait()
+	factories.finalize()
 	if c.MetadataFilePath != "" {
 		// we're done processing data. aggregate all the data from individual routines
-		metaData := aggregateMetadata(metaChan)
+		metaData := aggregateMetadata(metas)
 		metaData.StartTime = startTime
 		metaData.EndTime = time.Now().Format(c.TimeFormat)
 		metaData.NameServers = c.NameServers
@@ -221,22 +332,18 @@ func DoLookups(g GlobalLookupFactory, c *GlobalConf) error {
 		metaData.Conf = c
 		// add global lookup-related metadata
 		// write out metadata
-		var f *os.File
-		if c.MetadataFilePath == "-" {
-			f = os.Stderr
-		} else {
-			var err error
-			f, err = os.OpenFile(c.MetadataFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-			if err != nil {
-				log.Fatal("unable to open metadata file:", err.Error())
-			}
-			defer f.Close()
+		f, err := iohandlers.CreateMetadataOutput(c.MetadataFilePath, c.OutputCompression)
+		if err != nil {
+			log.Fatal("unable to open metadata file:", err.Error())
 		}
 		j, err := json.Marshal(metaData)
 		if err != nil {
 			log.Fatal("unable to JSON encode metadata:", err.Error())
 		}
-		f.WriteString(string(j))
+		f.Write(j)
+		if err := f.Close(); err != nil {
+			log.Fatal("unable to write metadata file:", err.Error())
+		}
 	}
 	return nil
 }
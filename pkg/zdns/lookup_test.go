@@ -0,0 +1,58 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/pflag"
+	"github.com/zmap/zdns/internal/metrics"
+	"gotest.tools/v3/assert"
+)
+
+func TestLookupModules(t *testing.T) {
+	gc := &GlobalConf{
+		TimeFormat: time.RFC3339,
+		Modules:    []string{"ECHOTEST", "BOGUS"},
+		flags:      pflag.NewFlagSet("test", pflag.ContinueOnError),
+	}
+	factories := newRoutineFactories(newFactorySet(gc), 0)
+	var res Result
+	statuses := lookupModules(factories, gc, lookupInput{Name: "example.com."}, &res)
+	assert.DeepEqual(t, statuses, []Status{STATUS_NOERROR, STATUS_ILLEGAL_INPUT})
+	assert.Equal(t, res.Name, "example.com.")
+	assert.Equal(t, res.AlteredName, "example.com")
+	assert.Equal(t, len(res.Results), 2)
+
+	echo := res.Results["ECHOTEST"]
+	assert.Equal(t, echo.Status, string(STATUS_NOERROR))
+	assert.Equal(t, echo.Name, "")
+	assert.DeepEqual(t, echo.Data, map[string]string{"name": "example.com", "nameserver": ""})
+
+	bogus := res.Results["BOGUS"]
+	assert.Equal(t, bogus.Status, string(STATUS_ILLEGAL_INPUT))
+	assert.Equal(t, bogus.Error, "unknown lookup module: BOGUS")
+}
+
+func TestLookupMetrics(t *testing.T) {
+	gc := &GlobalConf{TimeFormat: time.RFC3339}
+	lookups := testutil.ToFloat64(metrics.Lookups.WithLabelValues("ECHOTEST", string(STATUS_NOERROR)))
+	var res Result
+	status := runLookup(echoLookup{}, gc, "ECHOTEST", "example.com", "", &res)
+	assert.Equal(t, status, STATUS_NOERROR)
+	assert.Equal(t, testutil.ToFloat64(metrics.Lookups.WithLabelValues("ECHOTEST", string(STATUS_NOERROR))), lookups+1)
+}
@@ -0,0 +1,97 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zdns/iohandlers"
+)
+
+// OutputFormat is a format that results can be written in (--output-format)
+type OutputFormat struct {
+	// New creates the encoder of the format for a run with the given
+	// configuration
+	New func(gc *GlobalConf) (iohandlers.EncoderFactory, error)
+	// the format compresses its data itself according to
+	// --output-compression (e.g., Parquet), so the output files aren't
+	// compressed as a whole
+	Compressed bool
+}
+
+// keep a mapping from name to output format
+var outputFormats map[string]OutputFormat
+
+func RegisterOutputFormat(name string, f OutputFormat) {
+	if outputFormats == nil {
+		outputFormats = make(map[string]OutputFormat)
+	}
+	outputFormats[name] = f
+}
+
+func ValidOutputFormatsString() string {
+	valid := make([]string, 0, len(outputFormats))
+	for k := range outputFormats {
+		valid = append(valid, k)
+	}
+	sort.Strings(valid)
+	return strings.Join(valid, ", ")
+}
+
+func init() {
+	RegisterOutputFormat("json", OutputFormat{New: func(gc *GlobalConf) (iohandlers.EncoderFactory, error) {
+		return iohandlers.NewJSONEncoder, nil
+	}})
+	RegisterOutputFormat("csv", OutputFormat{New: csvOutputFormat})
+	RegisterOutputFormat("tsv", OutputFormat{New: csvOutputFormat})
+}
+
+func csvOutputFormat(gc *GlobalConf) (iohandlers.EncoderFactory, error) {
+	opts := iohandlers.CSVOptions{
+		TSV:          gc.OutputFormat == "tsv",
+		Groups:       gc.OutputGroups,
+		ModuleColumn: len(gc.Modules) > 0,
+		AlexaRank:    gc.AlexaFormat,
+		Metadata:     gc.MetadataFormat || gc.InputFormat == "jsonl",
+	}
+	// with per-line modules, any module can show up
+	if !gc.ModulePerLine && gc.InputFormat != "jsonl" {
+		opts.Modules = append([]string{gc.Module}, gc.Modules...)
+	}
+	return iohandlers.NewCSVEncoder(opts), nil
+}
+
+// outputEncoder returns the encoder for --output-format, and the compression
+// of the output files
+func outputEncoder(gc *GlobalConf) (iohandlers.EncoderFactory, string) {
+	format := gc.OutputFormat
+	if format == "" {
+		format = "json"
+	}
+	f, ok := outputFormats[format]
+	if !ok {
+		log.Fatal("Invalid output format. Options: ", ValidOutputFormatsString())
+	}
+	enc, err := f.New(gc)
+	if err != nil {
+		log.Fatal("Unable to set up output format: ", err.Error())
+	}
+	if f.Compressed {
+		return enc, iohandlers.CompressionNone
+	}
+	return enc, gc.OutputCompression
+}
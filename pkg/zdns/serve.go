@@ -0,0 +1,311 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"github.com/zmap/zdns/internal/metrics"
+	"github.com/zmap/zdns/internal/util"
+	"github.com/zmap/zdns/pkg/zdns/zdnspb"
+	"google.golang.org/grpc"
+)
+
+// maximum size of a lookup request body
+const maxRequestSize = 16 << 20
+
+var errServiceStopped = errors.New("lookup service is shutting down")
+
+// LookupRequest is a single lookup submitted to zdns serve
+type LookupRequest struct {
+	Module     string `json:"module"`
+	Name       string `json:"name"`
+	NameServer string `json:"nameserver,omitempty"`
+}
+
+type lookupJob struct {
+	req LookupRequest
+	// called from the worker routine with the filtered result
+	done func(res interface{})
+}
+
+// lookupService answers lookups for any registered module using a fixed pool
+// of worker routines. Unlike DoLookups, the factories (and with them the
+// sockets and caches) live for as long as the service does.
+type lookupService struct {
+	gc        *GlobalConf
+	factories *factorySet
+	jobs      chan *lookupJob
+	workers   sync.WaitGroup
+	// held for reading while a batch is being queued, so that stop doesn't
+	// close jobs underneath it
+	mu      sync.RWMutex
+	stopped bool
+}
+
+func newLookupService(gc *GlobalConf) *lookupService {
+	s := &lookupService{
+		gc:        gc,
+		factories: newFactorySet(gc),
+		jobs:      make(chan *lookupJob),
+	}
+	s.workers.Add(gc.Threads)
+	for i := 0; i < gc.Threads; i++ {
+		go s.worker(i)
+	}
+	return s
+}
+
+func (s *lookupService) worker(threadID int) {
+	defer s.workers.Done()
+	factories := newRoutineFactories(s.factories, threadID)
+	for job := range s.jobs {
+		job.done(s.resolve(factories, job.req))
+	}
+}
+
+func (s *lookupService) resolve(factories *routineFactories, req LookupRequest) interface{} {
+	res := Result{Name: req.Name}
+	module := strings.ToUpper(req.Module)
+	if module == "" {
+		module = "A"
+	}
+	var l Lookup
+	f, err := factories.get(module)
+	if err == nil {
+		l, err = f.MakeLookup()
+	}
+	if err != nil {
+		res.Status = string(STATUS_ILLEGAL_INPUT)
+		res.Error = err.Error()
+		res.Timestamp = time.Now().Format(s.gc.TimeFormat)
+	} else {
+		nameServer := ""
+		if req.NameServer != "" {
+			nameServer = util.AddDefaultPortToDNSServerName(req.NameServer)
+		}
+		runLookup(l, s.gc, module, req.Name, nameServer, &res)
+	}
+	data, err := filterResult(s.gc, res)
+	if err != nil {
+		log.Error("Unable to filter result: ", err)
+		return res
+	}
+	return data
+}
+
+// submit queues req, blocking while all of the workers are busy. done is
+// called with the result once the lookup completes.
+func (s *lookupService) submit(req LookupRequest, done func(res interface{})) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.stopped {
+		return errServiceStopped
+	}
+	s.jobs <- &lookupJob{req: req, done: done}
+	return nil
+}
+
+// lookup performs all of reqs concurrently and returns their results in the
+// same order
+func (s *lookupService) lookup(reqs []LookupRequest) ([]interface{}, error) {
+	var wg sync.WaitGroup
+	results := make([]interface{}, len(reqs))
+	for i := range reqs {
+		i := i
+		wg.Add(1)
+		err := s.submit(reqs[i], func(res interface{}) {
+			results[i] = res
+			wg.Done()
+		})
+		if err != nil {
+			wg.Done()
+			wg.Wait()
+			return nil, err
+		}
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// stop waits for outstanding lookups, shuts down the workers, and finalizes
+// every factory that was used
+func (s *lookupService) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	close(s.jobs)
+	s.mu.Unlock()
+	s.workers.Wait()
+	s.factories.finalize()
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func httpError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// ServeHTTP answers lookups at /lookup. A GET takes a single lookup from the
+// module, name and nameserver query parameters. A POST takes either a single
+// LookupRequest or an array of them, and is answered with a single result or
+// an array of results in the same order.
+func (s *lookupService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var reqs []LookupRequest
+	batch := false
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		reqs = []LookupRequest{{
+			Module:     q.Get("module"),
+			Name:       q.Get("name"),
+			NameServer: q.Get("nameserver"),
+		}}
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err)
+			return
+		}
+		body = bytes.TrimSpace(body)
+		if len(body) > 0 && body[0] == '[' {
+			batch = true
+			err = json.Unmarshal(body, &reqs)
+		} else {
+			reqs = make([]LookupRequest, 1)
+			err = json.Unmarshal(body, &reqs[0])
+		}
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		httpError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	results, err := s.lookup(reqs)
+	if err != nil {
+		httpError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	if batch {
+		writeJSON(w, http.StatusOK, results)
+	} else {
+		writeJSON(w, http.StatusOK, results[0])
+	}
+}
+
+// Serve answers lookups over HTTP on gc.HTTPAddr and over gRPC on
+// gc.GRPCAddr (either of which may be disabled by leaving it empty) until the
+// process receives SIGINT or SIGTERM. The arguments are the same as for Run, except that the
+// module is chosen per lookup rather than once for the whole process.
+func Serve(gc GlobalConf, flags *pflag.FlagSet,
+	timeout *int, iterationTimeout *int,
+	class_string *string, servers_string *string,
+	config_file *string, localaddr_string *string,
+	localif_string *string, nanoSeconds *bool) {
+
+	gc.flags = flags
+	setupGlobalConf(&gc, timeout, iterationTimeout, class_string, servers_string,
+		config_file, localaddr_string, localif_string, nanoSeconds)
+
+	if gc.NameServerMode || gc.AlexaFormat || gc.MetadataFormat {
+		log.Fatal("--name-server-mode, --alexa and --metadata-passthrough are not supported by serve")
+	}
+	if gc.MetricsAddr != "" {
+		if err := metrics.Serve(gc.MetricsAddr); err != nil {
+			log.Fatal("Unable to serve metrics: ", err.Error())
+		}
+	}
+
+	if gc.HTTPAddr == "" && gc.GRPCAddr == "" {
+		log.Fatal("At least one of --http-addr and --grpc-addr must be set")
+	}
+
+	service := newLookupService(&gc)
+	var srv *http.Server
+	if gc.HTTPAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/lookup", service)
+		ln, err := net.Listen("tcp", gc.HTTPAddr)
+		if err != nil {
+			log.Fatal("Unable to listen for HTTP requests: ", err.Error())
+		}
+		srv = &http.Server{Handler: mux}
+		go func() {
+			if err := srv.Serve(ln); err != http.ErrServerClosed {
+				log.Fatal("Unable to serve HTTP requests: ", err.Error())
+			}
+		}()
+		log.Info("serving lookups on http://", ln.Addr().String(), "/lookup")
+	}
+	var grpcSrv *grpc.Server
+	if gc.GRPCAddr != "" {
+		ln, err := net.Listen("tcp", gc.GRPCAddr)
+		if err != nil {
+			log.Fatal("Unable to listen for gRPC requests: ", err.Error())
+		}
+		grpcSrv = grpc.NewServer()
+		zdnspb.RegisterZdnsServer(grpcSrv, &grpcService{service: service})
+		go func() {
+			if err := grpcSrv.Serve(ln); err != nil {
+				log.Fatal("Unable to serve gRPC requests: ", err.Error())
+			}
+		}()
+		log.Info("serving gRPC lookups on ", ln.Addr().String())
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	<-sigs
+	log.Info("shutting down")
+	// give outstanding requests the chance to finish before tearing down the factories
+	ctx, cancel := context.WithTimeout(context.Background(), gc.Timeout)
+	defer cancel()
+	if srv != nil {
+		srv.Shutdown(ctx)
+	}
+	if grpcSrv != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcSrv.Stop()
+		}
+	}
+	service.stop()
+}
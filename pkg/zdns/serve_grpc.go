@@ -0,0 +1,163 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"sync"
+
+	"github.com/zmap/zdns/pkg/zdns/zdnspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// grpcService exposes a lookupService as the zdns.Zdns gRPC service
+type grpcService struct {
+	zdnspb.UnimplementedZdnsServer
+	service *lookupService
+}
+
+func targetedLookupRequest(t *zdnspb.TargetedDomain) LookupRequest {
+	req := LookupRequest{Module: t.Module, Name: t.Domain}
+	if len(t.Nameservers) > 0 {
+		req.NameServer = t.Nameservers[rand.Intn(len(t.Nameservers))]
+	}
+	return req
+}
+
+// resultProto converts a (filtered) result to its protobuf representation by
+// way of its JSON encoding, so that the protobuf result contains exactly what
+// the JSON output would
+func resultProto(res interface{}) (*zdnspb.Result, error) {
+	j, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		AlteredName string        `json:"altered_name"`
+		Name        string        `json:"name"`
+		Nameserver  string        `json:"nameserver"`
+		Class       string        `json:"class"`
+		AlexaRank   int64         `json:"alexa_rank"`
+		Metadata    interface{}   `json:"metadata"`
+		Status      string        `json:"status"`
+		Error       string        `json:"error"`
+		Timestamp   string        `json:"timestamp"`
+		Data        interface{}   `json:"data"`
+		Trace       []interface{} `json:"trace"`
+	}
+	if err := json.Unmarshal(j, &r); err != nil {
+		return nil, err
+	}
+	p := &zdnspb.Result{
+		AlteredName: r.AlteredName,
+		Name:        r.Name,
+		Nameserver:  r.Nameserver,
+		Class:       r.Class,
+		AlexaRank:   r.AlexaRank,
+		Status:      r.Status,
+		Error:       r.Error,
+		Timestamp:   r.Timestamp,
+	}
+	// metadata that isn't a string is passed on as JSON
+	switch m := r.Metadata.(type) {
+	case nil:
+	case string:
+		p.Metadata = m
+	default:
+		j, _ := json.Marshal(m)
+		p.Metadata = string(j)
+	}
+	if r.Data != nil {
+		if p.Data, err = structpb.NewValue(r.Data); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range r.Trace {
+		v, err := structpb.NewValue(t)
+		if err != nil {
+			return nil, err
+		}
+		p.Trace = append(p.Trace, v)
+	}
+	return p, nil
+}
+
+// Lookup submits every request read from the stream to the lookup service
+// and streams the results back as they complete. Each stream has as many
+// requests in flight as there are workers: results wait in a buffer of that
+// size until they are sent, so workers never wait for a slow reader, and the
+// stream stops reading requests while its buffer is full, which slows down
+// the client with gRPC's flow control rather than queueing up in memory.
+func (g *grpcService) Lookup(stream zdnspb.Zdns_LookupServer) error {
+	ctx := stream.Context()
+	size := g.service.gc.Threads
+	if size < 1 {
+		size = 1
+	}
+	// a slot is taken for each request until its result is sent
+	slots := make(chan struct{}, size)
+	results := make(chan *zdnspb.Result, size)
+	recvErr := make(chan error, 1)
+	go func() {
+		var pending sync.WaitGroup
+		defer func() {
+			pending.Wait()
+			close(results)
+		}()
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				recvErr <- ctx.Err()
+				return
+			}
+			pending.Add(1)
+			err = g.service.submit(targetedLookupRequest(req), func(res interface{}) {
+				defer pending.Done()
+				p, err := resultProto(res)
+				if err != nil {
+					p = &zdnspb.Result{Name: req.Domain, Status: string(STATUS_ERROR), Error: err.Error()}
+				}
+				// there is room for every result that holds a slot
+				results <- p
+			})
+			if err != nil {
+				pending.Done()
+				recvErr <- status.Error(codes.Unavailable, err.Error())
+				return
+			}
+		}
+	}()
+	for p := range results {
+		if err := stream.Send(p); err != nil {
+			return err
+		}
+		<-slots
+	}
+	return <-recvErr
+}
@@ -0,0 +1,113 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/zmap/zdns/pkg/zdns/zdnspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+)
+
+// serveTestGRPC serves s over gRPC in memory, and returns a function that
+// connects a client to it
+func serveTestGRPC(t *testing.T, s *lookupService) func() zdnspb.ZdnsClient {
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	zdnspb.RegisterZdnsServer(srv, &grpcService{service: s})
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	return func() zdnspb.ZdnsClient {
+		conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }))
+		assert.NilError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return zdnspb.NewZdnsClient(conn)
+	}
+}
+
+func TestGRPCLookup(t *testing.T) {
+	s := newTestService(t)
+	conn := serveTestGRPC(t, s)()
+
+	stream, err := conn.Lookup(context.Background())
+	assert.NilError(t, err)
+	names := []string{"a.com", "b.com", "c.com"}
+	for _, name := range names {
+		err := stream.Send(&zdnspb.TargetedDomain{Domain: name, Module: "echotest", Nameservers: []string{"1.1.1.1"}})
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, stream.Send(&zdnspb.TargetedDomain{Domain: "d.com", Module: "bogus"}))
+	assert.NilError(t, stream.CloseSend())
+
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		if res.Name == "d.com" {
+			assert.Equal(t, res.Status, string(STATUS_ILLEGAL_INPUT))
+			continue
+		}
+		assert.Equal(t, res.Status, string(STATUS_NOERROR))
+		data := res.Data.GetStructValue().AsMap()
+		assert.Equal(t, data["name"], res.Name)
+		assert.Equal(t, data["nameserver"], "1.1.1.1:53")
+		got = append(got, res.Name)
+	}
+	// results arrive as they complete, not necessarily in order
+	sort.Strings(got)
+	assert.DeepEqual(t, got, names)
+}
+
+func TestGRPCStalledStream(t *testing.T) {
+	s := newTestService(t)
+	dial := serveTestGRPC(t, s)
+
+	// a client that sends requests without reading the results
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stalled, err := dial().Lookup(ctx)
+	assert.NilError(t, err)
+	go func() {
+		for i := 0; i < 100000; i++ {
+			if stalled.Send(&zdnspb.TargetedDomain{Domain: fmt.Sprintf("%d.example.com", i), Module: "echotest"}) != nil {
+				return
+			}
+		}
+	}()
+	time.Sleep(500 * time.Millisecond)
+
+	// doesn't wait for the stalled client
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel2()
+	stream, err := dial().Lookup(ctx2)
+	assert.NilError(t, err)
+	assert.NilError(t, stream.Send(&zdnspb.TargetedDomain{Domain: "example.com", Module: "echotest"}))
+	assert.NilError(t, stream.CloseSend())
+	res, err := stream.Recv()
+	assert.NilError(t, err)
+	assert.Equal(t, res.Name, "example.com")
+}
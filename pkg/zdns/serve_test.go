@@ -0,0 +1,106 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+type echoLookup struct{}
+
+func (echoLookup) DoLookup(name, nameServer string) (interface{}, Trace, Status, error) {
+	return map[string]string{"name": name, "nameserver": nameServer}, nil, STATUS_NOERROR, nil
+}
+
+type echoFactory struct {
+	BaseGlobalLookupFactory
+	initialized int
+}
+
+func (f *echoFactory) Initialize(c *GlobalConf) error {
+	f.initialized++
+	return f.BaseGlobalLookupFactory.Initialize(c)
+}
+
+func (f *echoFactory) MakeRoutineFactory(int) (RoutineLookupFactory, error) {
+	return f, nil
+}
+
+func (f *echoFactory) MakeLookup() (Lookup, error) {
+	return echoLookup{}, nil
+}
+
+var echo = new(echoFactory)
+
+func init() {
+	RegisterLookup("ECHOTEST", echo)
+}
+
+func newTestService(t *testing.T) *lookupService {
+	gc := &GlobalConf{
+		Threads:      2,
+		TimeFormat:   time.RFC3339,
+		OutputGroups: []string{"short"},
+		flags:        pflag.NewFlagSet("test", pflag.ContinueOnError),
+	}
+	s := newLookupService(gc)
+	t.Cleanup(s.stop)
+	return s
+}
+
+func serveTestRequest(s *lookupService, method, target, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return w
+}
+
+func TestServeGet(t *testing.T) {
+	s := newTestService(t)
+	w := serveTestRequest(s, http.MethodGet, "/lookup?module=echotest&name=example.com&nameserver=1.2.3.4", "")
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Assert(t, strings.Contains(w.Body.String(), `"data":{"name":"example.com","nameserver":"1.2.3.4:53"}`), w.Body.String())
+	assert.Assert(t, strings.Contains(w.Body.String(), `"status":"NOERROR"`), w.Body.String())
+}
+
+func TestServeBatch(t *testing.T) {
+	s := newTestService(t)
+	before := echo.initialized
+	w := serveTestRequest(s, http.MethodPost, "/lookup",
+		`[{"module":"ECHOTEST","name":"a.com"},{"module":"BOGUS","name":"b.com"},{"module":"ECHOTEST","name":"c.com."}]`)
+	assert.Equal(t, w.Code, http.StatusOK)
+	body := w.Body.String()
+	assert.Assert(t, strings.HasPrefix(body, "["), body)
+	a := strings.Index(body, `"name":"a.com"`)
+	b := strings.Index(body, `"status":"ILLEGAL_INPUT"`)
+	c := strings.Index(body, `"altered_name":"c.com"`)
+	assert.Assert(t, a >= 0 && a < b && b < c, body)
+	// the factory is only initialized once, however many routines use it
+	assert.Equal(t, echo.initialized-before, 1)
+}
+
+func TestServeBadRequest(t *testing.T) {
+	s := newTestService(t)
+	w := serveTestRequest(s, http.MethodPost, "/lookup", `{"module":`)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	w = serveTestRequest(s, http.MethodDelete, "/lookup", "")
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed)
+}
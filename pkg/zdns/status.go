@@ -0,0 +1,171 @@
+/*
+ * ZDNS Copyright 2022 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zdns
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// statusSnapshot is a point-in-time view of the progress of all lookup routines
+type statusSnapshot struct {
+	Time      time.Time
+	Names     int
+	InFlight  int
+	Status    map[Status]int
+	InputRead int64
+	InputSize int64
+}
+
+// statusHandler periodically writes a single line summarizing the progress of
+// DoLookups. Everything it reports is read from the per-routine metadata, so
+// the lookup routines themselves don't need to know that it exists.
+type statusHandler struct {
+	w        io.Writer
+	f        *os.File
+	interval time.Duration
+	start    time.Time
+	metas    []*routineMetadata
+	input    InputHandler
+	last     statusSnapshot
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+func newStatusHandler(gc *GlobalConf, metas []*routineMetadata, input InputHandler) *statusHandler {
+	h := &statusHandler{
+		interval: gc.StatusUpdatesInterval,
+		start:    time.Now(),
+		metas:    metas,
+		input:    input,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if h.interval <= 0 {
+		h.interval = time.Second
+	}
+	if gc.StatusUpdatesFilePath == "-" {
+		h.w = os.Stderr
+	} else {
+		f, err := os.OpenFile(gc.StatusUpdatesFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			log.Fatal("unable to open status updates file:", err.Error())
+		}
+		h.f = f
+		h.w = f
+	}
+	h.last = statusSnapshot{Time: h.start}
+	return h
+}
+
+func (h *statusHandler) snapshot() statusSnapshot {
+	s := statusSnapshot{Time: time.Now(), Status: make(map[Status]int)}
+	for _, m := range h.metas {
+		m.Lock()
+		s.Names += m.Names
+		s.InFlight += m.InFlight
+		for k, v := range m.Status {
+			s.Status[k] += v
+		}
+		m.Unlock()
+	}
+	if p, ok := h.input.(ProgressInputHandler); ok {
+		s.InputRead, s.InputSize = p.Progress()
+	}
+	return s
+}
+
+func (h *statusHandler) report() {
+	cur := h.snapshot()
+	io.WriteString(h.w, formatStatusLine(h.start, h.last, cur)+"\n")
+	h.last = cur
+}
+
+// run writes a status line every interval until stop is called
+func (h *statusHandler) run() {
+	defer close(h.done)
+	t := time.NewTicker(h.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			h.report()
+		case <-h.quit:
+			// always finish with the final totals
+			h.report()
+			return
+		}
+	}
+}
+
+func (h *statusHandler) stop() {
+	close(h.quit)
+	<-h.done
+	if h.f != nil {
+		h.f.Close()
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+func formatStatusLine(start time.Time, prev, cur statusSnapshot) string {
+	elapsed := cur.Time.Sub(start)
+	var avgRate, curRate float64
+	if elapsed > 0 {
+		avgRate = float64(cur.Names) / elapsed.Seconds()
+	}
+	if window := cur.Time.Sub(prev.Time); window > 0 {
+		curRate = float64(cur.Names-prev.Names) / window.Seconds()
+	}
+	statuses := make([]string, 0, len(cur.Status))
+	for k := range cur.Status {
+		statuses = append(statuses, string(k))
+	}
+	// most common statuses first, so the interesting part of the line doesn't move around
+	sort.Slice(statuses, func(i, j int) bool {
+		ci, cj := cur.Status[Status(statuses[i])], cur.Status[Status(statuses[j])]
+		if ci != cj {
+			return ci > cj
+		}
+		return statuses[i] < statuses[j]
+	})
+	for i, k := range statuses {
+		statuses[i] = fmt.Sprintf("%s: %d", k, cur.Status[Status(k)])
+	}
+
+	line := fmt.Sprintf("%s %s elapsed; %d names (%.1f/s, avg %.1f/s); %d in flight",
+		cur.Time.Format(time.RFC3339), formatDuration(elapsed), cur.Names, curRate, avgRate, cur.InFlight)
+	if len(statuses) > 0 {
+		line += "; " + strings.Join(statuses, ", ")
+	}
+	if cur.InputSize > 0 {
+		done := float64(cur.InputRead) / float64(cur.InputSize)
+		line += fmt.Sprintf("; %.1f%% of input read", 100*done)
+		if done > 0 && done < 1 {
+			eta := time.Duration(float64(elapsed) * (1 - done) / done)
+			line += ", ETA " + formatDuration(eta)
+		}
+	}
+	return line
+}
@@ -0,0 +1,31 @@
+package zdns
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFormatStatusLine(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := statusSnapshot{Time: start.Add(9 * time.Second), Names: 80}
+	cur := statusSnapshot{
+		Time:      start.Add(10 * time.Second),
+		Names:     100,
+		InFlight:  7,
+		Status:    map[Status]int{STATUS_NOERROR: 90, STATUS_NXDOMAIN: 6, STATUS_TIMEOUT: 4},
+		InputRead: 250,
+		InputSize: 1000,
+	}
+	line := formatStatusLine(start, prev, cur)
+	assert.Equal(t, line, "2022-01-01T00:00:10Z 0:00:10 elapsed; 100 names (20.0/s, avg 10.0/s); 7 in flight; "+
+		"NOERROR: 90, NXDOMAIN: 6, TIMEOUT: 4; 25.0% of input read, ETA 0:00:30")
+}
+
+func TestFormatStatusLineUnknownInputSize(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cur := statusSnapshot{Time: start.Add(2 * time.Hour), Names: 0}
+	line := formatStatusLine(start, statusSnapshot{Time: start}, cur)
+	assert.Equal(t, line, "2022-01-01T02:00:00Z 2:00:00 elapsed; 0 names (0.0/s, avg 0.0/s); 0 in flight")
+}
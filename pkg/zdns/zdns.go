@@ -26,6 +26,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/zmap/dns"
+	"github.com/zmap/zdns/internal/metrics"
 	"github.com/zmap/zdns/internal/util"
 	"github.com/zmap/zdns/iohandlers"
 )
@@ -36,6 +37,24 @@ func Run(gc GlobalConf, flags *pflag.FlagSet,
 	config_file *string, localaddr_string *string,
 	localif_string *string, nanoSeconds *bool) {
 
+	if len(gc.Modules) > 0 {
+		if gc.Module != "" {
+			log.Fatal("A module can't be given together with --modules")
+		}
+		for _, module := range gc.Modules {
+			f := GetLookup(module)
+			if f == nil {
+				log.Fatal("Invalid lookup module specified (", module, "). Valid modules: ", ValidlookupsString())
+			}
+			if !f.AllowStdIn() && gc.InputFilePath == "-" {
+				log.Fatal("Module ", module, " does not allow reading from stdin")
+			}
+		}
+		// the first module is set up up front, the others when the first
+		// input line is looked up
+		gc.Module = gc.Modules[0]
+	}
+
 	factory := GetLookup(gc.Module)
 
 	if factory == nil {
@@ -43,6 +62,101 @@ func Run(gc GlobalConf, flags *pflag.FlagSet,
 	}
 
 	factory.SetFlags(flags)
+	gc.flags = flags
+
+	setupGlobalConf(&gc, timeout, iterationTimeout, class_string, servers_string,
+		config_file, localaddr_string, localif_string, nanoSeconds)
+
+	// some modules require multiple passes over a file (this is really just the case for zone files)
+	if !factory.AllowStdIn() && gc.InputFilePath == "-" {
+		log.Fatal("Specified module does not allow reading from stdin")
+	}
+
+	// setup i/o
+	if gc.ExpandRanges {
+		// a missing flag (e.g., when called as a library) just means no blacklist
+		blacklistPath, _ := flags.GetString("blacklist-file")
+		seed := gc.PermutationSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		h, err := iohandlers.NewRangeInputHandler(gc.InputFilePath, blacklistPath, gc.PermuteRanges, seed)
+		if err != nil {
+			log.Fatal("Unable to set up range expansion: ", err.Error())
+		}
+		h.Compression = gc.InputCompression
+		gc.InputHandler = h
+	} else {
+		h := iohandlers.NewFileInputHandler(gc.InputFilePath)
+		h.Compression = gc.InputCompression
+		gc.InputHandler = h
+	}
+	rotation := iohandlers.OutputRotation{
+		Records:  gc.OutputRotateRecords,
+		Bytes:    gc.OutputRotateBytes,
+		Interval: gc.OutputRotateInterval,
+	}
+	encoder, compression := outputEncoder(&gc)
+	if rotation.Enabled() || gc.OutputShards > 1 {
+		h, err := iohandlers.NewRotatingOutputHandler(gc.OutputFilePath, rotation, gc.OutputShards)
+		if err != nil {
+			log.Fatal("Unable to set up output rotation: ", err.Error())
+		}
+		h.Compression = compression
+		h.Encoder = encoder
+		gc.OutputHandler = h
+	} else {
+		h := iohandlers.NewFileOutputHandler(gc.OutputFilePath)
+		h.Compression = compression
+		h.Encoder = encoder
+		gc.OutputHandler = h
+	}
+
+	// allow the factory to initialize itself
+	if err := factory.Initialize(&gc); err != nil {
+		log.Fatal("Factory was unable to initialize:", err.Error())
+	}
+	if gc.MetricsAddr != "" {
+		if err := metrics.Serve(gc.MetricsAddr); err != nil {
+			log.Fatal("Unable to serve metrics: ", err.Error())
+		}
+	}
+	// run it.
+	if err := DoLookups(factory, &gc); err != nil {
+		log.Fatal("Unable to run lookups:", err.Error())
+	}
+	// allow the factory to finalize itself
+	if err := factory.Finalize(); err != nil {
+		log.Fatal("Factory was unable to finalize:", err.Error())
+	}
+}
+
+// parseClass translates the name of a DNS class, as given to --class, to its value
+func parseClass(name string) (uint16, bool) {
+	switch strings.ToUpper(name) {
+	case "INET", "IN":
+		return dns.ClassINET, true
+	case "CSNET", "CS":
+		return dns.ClassCSNET, true
+	case "CHAOS", "CH":
+		return dns.ClassCHAOS, true
+	case "HESIOD", "HS":
+		return dns.ClassHESIOD, true
+	case "NONE":
+		return dns.ClassNONE, true
+	case "ANY":
+		return dns.ClassANY, true
+	}
+	return 0, false
+}
+
+// setupGlobalConf completes the module-independent parts of gc from the
+// command line arguments that aren't stored in GlobalConf directly
+func setupGlobalConf(gc *GlobalConf,
+	timeout *int, iterationTimeout *int,
+	class_string *string, servers_string *string,
+	config_file *string, localaddr_string *string,
+	localif_string *string, nanoSeconds *bool) {
 
 	if gc.LogFilePath != "" {
 		f, err := os.OpenFile(gc.LogFilePath, os.O_WRONLY|os.O_CREATE, 0666)
@@ -73,23 +187,11 @@ func Run(gc GlobalConf, flags *pflag.FlagSet,
 	gc.IterationTimeout = time.Duration(time.Second * time.Duration(*iterationTimeout))
 
 	// class initialization
-	switch strings.ToUpper(*class_string) {
-	case "INET", "IN":
-		gc.Class = dns.ClassINET
-	case "CSNET", "CS":
-		gc.Class = dns.ClassCSNET
-	case "CHAOS", "CH":
-		gc.Class = dns.ClassCHAOS
-	case "HESIOD", "HS":
-		gc.Class = dns.ClassHESIOD
-	case "NONE":
-		gc.Class = dns.ClassNONE
-	case "ANY":
-		gc.Class = dns.ClassANY
-	default:
+	class, ok := parseClass(*class_string)
+	if !ok {
 		log.Fatal("Unknown record class specified. Valid valued are INET (default), CSNET, CHAOS, HESIOD, NONE, ANY")
-
 	}
+	gc.Class = class
 
 	if *servers_string == "" {
 		// if we're doing recursive resolution, figure out default OS name servers
@@ -185,6 +287,33 @@ func Run(gc GlobalConf, flags *pflag.FlagSet,
 	if gc.UDPOnly && gc.TCPOnly {
 		log.Fatal("TCP Only and UDP Only are conflicting")
 	}
+	if !iohandlers.ValidCompression(gc.InputCompression) || !iohandlers.ValidCompression(gc.OutputCompression) {
+		log.Fatal("Invalid compression. Options: auto, none, gzip, zstd")
+	}
+	if gc.OutputRotateRecords < 0 || gc.OutputRotateBytes < 0 || gc.OutputRotateInterval < 0 || gc.OutputShards < 0 {
+		log.Fatal("Output rotation limits and the number of output shards can't be negative")
+	}
+	if _, ok := outputFormats[gc.OutputFormat]; !ok && gc.OutputFormat != "" {
+		log.Fatal("Invalid output format. Options: ", ValidOutputFormatsString())
+	}
+	switch gc.InputFormat {
+	case "text":
+	case "jsonl":
+		if gc.AlexaFormat || gc.MetadataFormat || gc.NameServerMode || gc.ModulePerLine || gc.ExpandRanges {
+			log.Fatal("--alexa, --metadata-passthrough, --name-server-mode, --module-per-line and --expand-ranges can't be used with --input-format=jsonl")
+		}
+	default:
+		log.Fatal("Invalid input format. Options: text, jsonl")
+	}
+	if gc.ModulePerLine && (gc.AlexaFormat || gc.MetadataFormat || gc.NameServerMode) {
+		log.Fatal("--module-per-line is incompatible with --alexa, --metadata-passthrough and --name-server-mode")
+	}
+	if gc.ExpandRanges && gc.AlexaFormat {
+		log.Fatal("--expand-ranges is incompatible with --alexa")
+	}
+	if gc.PermuteRanges && !gc.ExpandRanges {
+		log.Fatal("--permute-ranges requires --expand-ranges")
+	}
 	if gc.NameServerMode && gc.AlexaFormat {
 		log.Fatal("Alexa mode is incompatible with name server mode")
 	}
@@ -203,28 +332,8 @@ func Run(gc GlobalConf, flags *pflag.FlagSet,
 	gc.OutputGroups = append(gc.OutputGroups, gc.ResultVerbosity)
 	gc.OutputGroups = append(gc.OutputGroups, groups...)
 
+	gc.Shared = NewSharedState()
+
 	// Seeding for RandomNameServer()
 	rand.Seed(time.Now().UnixNano())
-
-	// some modules require multiple passes over a file (this is really just the case for zone files)
-	if !factory.AllowStdIn() && gc.InputFilePath == "-" {
-		log.Fatal("Specified module does not allow reading from stdin")
-	}
-
-	// setup i/o
-	gc.InputHandler = iohandlers.NewFileInputHandler(gc.InputFilePath)
-	gc.OutputHandler = iohandlers.NewFileOutputHandler(gc.OutputFilePath)
-
-	// allow the factory to initialize itself
-	if err := factory.Initialize(&gc); err != nil {
-		log.Fatal("Factory was unable to initialize:", err.Error())
-	}
-	// run it.
-	if err := DoLookups(factory, &gc); err != nil {
-		log.Fatal("Unable to run lookups:", err.Error())
-	}
-	// allow the factory to finalize itself
-	if err := factory.Finalize(); err != nil {
-		log.Fatal("Factory was unable to finalize:", err.Error())
-	}
 }
@@ -0,0 +1,4223 @@
+//
+// ZDNS Copyright 2022 Regents of the University of Michigan
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Typed schema of ZDNS results, as written by --output-format=protobuf. Field
+// names follow the JSON output. Fields are only ever added to this version of
+// the schema; incompatible changes get a new package (zdns.v2).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: zdns_result.proto
+
+package zdnsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Result is the result of looking up a single name (zdns.Result).
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AlteredName string          `protobuf:"bytes,1,opt,name=altered_name,json=alteredName,proto3" json:"altered_name,omitempty"`
+	Name        string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Nameserver  string          `protobuf:"bytes,3,opt,name=nameserver,proto3" json:"nameserver,omitempty"`
+	Class       string          `protobuf:"bytes,4,opt,name=class,proto3" json:"class,omitempty"`
+	AlexaRank   int64           `protobuf:"varint,5,opt,name=alexa_rank,json=alexaRank,proto3" json:"alexa_rank,omitempty"`
+	Metadata    *structpb.Value `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Status      string          `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Error       string          `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Timestamp   string          `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// data returned by the module, which depends on the module
+	//
+	// Types that are assignable to Data:
+	//	*Result_Dns
+	//	*Result_Ip
+	//	*Result_Mx
+	//	*Result_Ns
+	//	*Result_Other
+	Data  isResult_Data `protobuf_oneof:"data"`
+	Trace []*TraceStep  `protobuf:"bytes,15,rep,name=trace,proto3" json:"trace,omitempty"`
+	// one result per module when running with --modules
+	Results map[string]*Result `protobuf:"bytes,16,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Result) GetAlteredName() string {
+	if x != nil {
+		return x.AlteredName
+	}
+	return ""
+}
+
+func (x *Result) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Result) GetNameserver() string {
+	if x != nil {
+		return x.Nameserver
+	}
+	return ""
+}
+
+func (x *Result) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *Result) GetAlexaRank() int64 {
+	if x != nil {
+		return x.AlexaRank
+	}
+	return 0
+}
+
+func (x *Result) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Result) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Result) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (m *Result) GetData() isResult_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *Result) GetDns() *DNSResult {
+	if x, ok := x.GetData().(*Result_Dns); ok {
+		return x.Dns
+	}
+	return nil
+}
+
+func (x *Result) GetIp() *IPResult {
+	if x, ok := x.GetData().(*Result_Ip); ok {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *Result) GetMx() *MXResult {
+	if x, ok := x.GetData().(*Result_Mx); ok {
+		return x.Mx
+	}
+	return nil
+}
+
+func (x *Result) GetNs() *NSResult {
+	if x, ok := x.GetData().(*Result_Ns); ok {
+		return x.Ns
+	}
+	return nil
+}
+
+func (x *Result) GetOther() *structpb.Value {
+	if x, ok := x.GetData().(*Result_Other); ok {
+		return x.Other
+	}
+	return nil
+}
+
+func (x *Result) GetTrace() []*TraceStep {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+func (x *Result) GetResults() map[string]*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type isResult_Data interface {
+	isResult_Data()
+}
+
+type Result_Dns struct {
+	// raw lookups, e.g., A or MX
+	Dns *DNSResult `protobuf:"bytes,10,opt,name=dns,proto3,oneof"`
+}
+
+type Result_Ip struct {
+	// ALOOKUP
+	Ip *IPResult `protobuf:"bytes,11,opt,name=ip,proto3,oneof"`
+}
+
+type Result_Mx struct {
+	// MXLOOKUP
+	Mx *MXResult `protobuf:"bytes,12,opt,name=mx,proto3,oneof"`
+}
+
+type Result_Ns struct {
+	// NSLOOKUP
+	Ns *NSResult `protobuf:"bytes,13,opt,name=ns,proto3,oneof"`
+}
+
+type Result_Other struct {
+	// other modules, as in the JSON output
+	Other *structpb.Value `protobuf:"bytes,14,opt,name=other,proto3,oneof"`
+}
+
+func (*Result_Dns) isResult_Data() {}
+
+func (*Result_Ip) isResult_Data() {}
+
+func (*Result_Mx) isResult_Data() {}
+
+func (*Result_Ns) isResult_Data() {}
+
+func (*Result_Other) isResult_Data() {}
+
+// DNSResult is a DNS response (miekg.Result).
+type DNSResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answers     []*Answer `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	Additionals []*Answer `protobuf:"bytes,2,rep,name=additionals,proto3" json:"additionals,omitempty"`
+	Authorities []*Answer `protobuf:"bytes,3,rep,name=authorities,proto3" json:"authorities,omitempty"`
+	Protocol    string    `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Resolver    string    `protobuf:"bytes,5,opt,name=resolver,proto3" json:"resolver,omitempty"`
+	Flags       *DNSFlags `protobuf:"bytes,6,opt,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *DNSResult) Reset() {
+	*x = DNSResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSResult) ProtoMessage() {}
+
+func (x *DNSResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSResult.ProtoReflect.Descriptor instead.
+func (*DNSResult) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DNSResult) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *DNSResult) GetAdditionals() []*Answer {
+	if x != nil {
+		return x.Additionals
+	}
+	return nil
+}
+
+func (x *DNSResult) GetAuthorities() []*Answer {
+	if x != nil {
+		return x.Authorities
+	}
+	return nil
+}
+
+func (x *DNSResult) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *DNSResult) GetResolver() string {
+	if x != nil {
+		return x.Resolver
+	}
+	return ""
+}
+
+func (x *DNSResult) GetFlags() *DNSFlags {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type DNSFlags struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Response           bool  `protobuf:"varint,1,opt,name=response,proto3" json:"response,omitempty"`
+	Opcode             int32 `protobuf:"varint,2,opt,name=opcode,proto3" json:"opcode,omitempty"`
+	Authoritative      bool  `protobuf:"varint,3,opt,name=authoritative,proto3" json:"authoritative,omitempty"`
+	Truncated          bool  `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	RecursionDesired   bool  `protobuf:"varint,5,opt,name=recursion_desired,json=recursionDesired,proto3" json:"recursion_desired,omitempty"`
+	RecursionAvailable bool  `protobuf:"varint,6,opt,name=recursion_available,json=recursionAvailable,proto3" json:"recursion_available,omitempty"`
+	Authenticated      bool  `protobuf:"varint,7,opt,name=authenticated,proto3" json:"authenticated,omitempty"`
+	CheckingDisabled   bool  `protobuf:"varint,8,opt,name=checking_disabled,json=checkingDisabled,proto3" json:"checking_disabled,omitempty"`
+	ErrorCode          int32 `protobuf:"varint,9,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+}
+
+func (x *DNSFlags) Reset() {
+	*x = DNSFlags{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSFlags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSFlags) ProtoMessage() {}
+
+func (x *DNSFlags) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSFlags.ProtoReflect.Descriptor instead.
+func (*DNSFlags) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DNSFlags) GetResponse() bool {
+	if x != nil {
+		return x.Response
+	}
+	return false
+}
+
+func (x *DNSFlags) GetOpcode() int32 {
+	if x != nil {
+		return x.Opcode
+	}
+	return 0
+}
+
+func (x *DNSFlags) GetAuthoritative() bool {
+	if x != nil {
+		return x.Authoritative
+	}
+	return false
+}
+
+func (x *DNSFlags) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *DNSFlags) GetRecursionDesired() bool {
+	if x != nil {
+		return x.RecursionDesired
+	}
+	return false
+}
+
+func (x *DNSFlags) GetRecursionAvailable() bool {
+	if x != nil {
+		return x.RecursionAvailable
+	}
+	return false
+}
+
+func (x *DNSFlags) GetAuthenticated() bool {
+	if x != nil {
+		return x.Authenticated
+	}
+	return false
+}
+
+func (x *DNSFlags) GetCheckingDisabled() bool {
+	if x != nil {
+		return x.CheckingDisabled
+	}
+	return false
+}
+
+func (x *DNSFlags) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+// TraceStep is a query made during iterative resolution (miekg.TraceStep).
+type TraceStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results    *DNSResult `protobuf:"bytes,1,opt,name=results,proto3" json:"results,omitempty"`
+	Type       uint32     `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Class      uint32     `protobuf:"varint,3,opt,name=class,proto3" json:"class,omitempty"`
+	Name       string     `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	NameServer string     `protobuf:"bytes,5,opt,name=name_server,json=nameServer,proto3" json:"name_server,omitempty"`
+	Depth      int32      `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	Layer      string     `protobuf:"bytes,7,opt,name=layer,proto3" json:"layer,omitempty"`
+	Cached     bool       `protobuf:"varint,8,opt,name=cached,proto3" json:"cached,omitempty"`
+}
+
+func (x *TraceStep) Reset() {
+	*x = TraceStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceStep) ProtoMessage() {}
+
+func (x *TraceStep) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceStep.ProtoReflect.Descriptor instead.
+func (*TraceStep) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TraceStep) GetResults() *DNSResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *TraceStep) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *TraceStep) GetClass() uint32 {
+	if x != nil {
+		return x.Class
+	}
+	return 0
+}
+
+func (x *TraceStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TraceStep) GetNameServer() string {
+	if x != nil {
+		return x.NameServer
+	}
+	return ""
+}
+
+func (x *TraceStep) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *TraceStep) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
+func (x *TraceStep) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+// IPResult holds the addresses found by ALOOKUP.
+type IPResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ipv4Addresses []string `protobuf:"bytes,1,rep,name=ipv4_addresses,json=ipv4Addresses,proto3" json:"ipv4_addresses,omitempty"`
+	Ipv6Addresses []string `protobuf:"bytes,2,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"`
+}
+
+func (x *IPResult) Reset() {
+	*x = IPResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IPResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPResult) ProtoMessage() {}
+
+func (x *IPResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPResult.ProtoReflect.Descriptor instead.
+func (*IPResult) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IPResult) GetIpv4Addresses() []string {
+	if x != nil {
+		return x.Ipv4Addresses
+	}
+	return nil
+}
+
+func (x *IPResult) GetIpv6Addresses() []string {
+	if x != nil {
+		return x.Ipv6Addresses
+	}
+	return nil
+}
+
+type MXResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchanges []*MXRecord `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+}
+
+func (x *MXResult) Reset() {
+	*x = MXResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MXResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MXResult) ProtoMessage() {}
+
+func (x *MXResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MXResult.ProtoReflect.Descriptor instead.
+func (*MXResult) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MXResult) GetExchanges() []*MXRecord {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+type MXRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Class         string   `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Preference    uint32   `protobuf:"varint,4,opt,name=preference,proto3" json:"preference,omitempty"`
+	Ipv4Addresses []string `protobuf:"bytes,5,rep,name=ipv4_addresses,json=ipv4Addresses,proto3" json:"ipv4_addresses,omitempty"`
+	Ipv6Addresses []string `protobuf:"bytes,6,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"`
+	Ttl           uint32   `protobuf:"varint,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *MXRecord) Reset() {
+	*x = MXRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MXRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MXRecord) ProtoMessage() {}
+
+func (x *MXRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MXRecord.ProtoReflect.Descriptor instead.
+func (*MXRecord) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MXRecord) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MXRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MXRecord) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *MXRecord) GetPreference() uint32 {
+	if x != nil {
+		return x.Preference
+	}
+	return 0
+}
+
+func (x *MXRecord) GetIpv4Addresses() []string {
+	if x != nil {
+		return x.Ipv4Addresses
+	}
+	return nil
+}
+
+func (x *MXRecord) GetIpv6Addresses() []string {
+	if x != nil {
+		return x.Ipv6Addresses
+	}
+	return nil
+}
+
+func (x *MXRecord) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type NSResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers []*NSRecord `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *NSResult) Reset() {
+	*x = NSResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NSResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NSResult) ProtoMessage() {}
+
+func (x *NSResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NSResult.ProtoReflect.Descriptor instead.
+func (*NSResult) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NSResult) GetServers() []*NSRecord {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type NSRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Ipv4Addresses []string `protobuf:"bytes,3,rep,name=ipv4_addresses,json=ipv4Addresses,proto3" json:"ipv4_addresses,omitempty"`
+	Ipv6Addresses []string `protobuf:"bytes,4,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"`
+	Ttl           uint32   `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *NSRecord) Reset() {
+	*x = NSRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NSRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NSRecord) ProtoMessage() {}
+
+func (x *NSRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NSRecord.ProtoReflect.Descriptor instead.
+func (*NSRecord) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NSRecord) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NSRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NSRecord) GetIpv4Addresses() []string {
+	if x != nil {
+		return x.Ipv4Addresses
+	}
+	return nil
+}
+
+func (x *NSRecord) GetIpv6Addresses() []string {
+	if x != nil {
+		return x.Ipv6Addresses
+	}
+	return nil
+}
+
+func (x *NSRecord) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+// Answer is a resource record. Records whose data is a single name, address
+// or string (e.g., A, NS, TXT) have it in answer; other types have a message
+// of their own.
+type Answer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type  string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Class string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Ttl   uint32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Types that are assignable to Data:
+	//	*Answer_Answer
+	//	*Answer_Pref
+	//	*Answer_Soa
+	//	*Answer_Srv
+	//	*Answer_Svcb
+	//	*Answer_Dnskey
+	//	*Answer_Ds
+	//	*Answer_Rrsig
+	//	*Answer_Caa
+	//	*Answer_Tlsa
+	//	*Answer_Smimea
+	//	*Answer_Nsec
+	//	*Answer_Nsec3
+	//	*Answer_Naptr
+	//	*Answer_Sshfp
+	//	*Answer_Uri
+	//	*Answer_Afsdb
+	//	*Answer_Cert
+	//	*Answer_Gpos
+	//	*Answer_Hinfo
+	//	*Answer_Hip
+	//	*Answer_Loc
+	//	*Answer_Minfo
+	//	*Answer_Px
+	//	*Answer_Rp
+	//	*Answer_Talink
+	//	*Answer_Tkey
+	Data isAnswer_Data `protobuf_oneof:"data"`
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Answer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Answer) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Answer) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *Answer) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (m *Answer) GetData() isAnswer_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *Answer) GetAnswer() string {
+	if x, ok := x.GetData().(*Answer_Answer); ok {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *Answer) GetPref() *PrefAnswer {
+	if x, ok := x.GetData().(*Answer_Pref); ok {
+		return x.Pref
+	}
+	return nil
+}
+
+func (x *Answer) GetSoa() *SOAAnswer {
+	if x, ok := x.GetData().(*Answer_Soa); ok {
+		return x.Soa
+	}
+	return nil
+}
+
+func (x *Answer) GetSrv() *SRVAnswer {
+	if x, ok := x.GetData().(*Answer_Srv); ok {
+		return x.Srv
+	}
+	return nil
+}
+
+func (x *Answer) GetSvcb() *SVCBAnswer {
+	if x, ok := x.GetData().(*Answer_Svcb); ok {
+		return x.Svcb
+	}
+	return nil
+}
+
+func (x *Answer) GetDnskey() *DNSKEYAnswer {
+	if x, ok := x.GetData().(*Answer_Dnskey); ok {
+		return x.Dnskey
+	}
+	return nil
+}
+
+func (x *Answer) GetDs() *DSAnswer {
+	if x, ok := x.GetData().(*Answer_Ds); ok {
+		return x.Ds
+	}
+	return nil
+}
+
+func (x *Answer) GetRrsig() *RRSIGAnswer {
+	if x, ok := x.GetData().(*Answer_Rrsig); ok {
+		return x.Rrsig
+	}
+	return nil
+}
+
+func (x *Answer) GetCaa() *CAAAnswer {
+	if x, ok := x.GetData().(*Answer_Caa); ok {
+		return x.Caa
+	}
+	return nil
+}
+
+func (x *Answer) GetTlsa() *TLSAAnswer {
+	if x, ok := x.GetData().(*Answer_Tlsa); ok {
+		return x.Tlsa
+	}
+	return nil
+}
+
+func (x *Answer) GetSmimea() *SMIMEAAnswer {
+	if x, ok := x.GetData().(*Answer_Smimea); ok {
+		return x.Smimea
+	}
+	return nil
+}
+
+func (x *Answer) GetNsec() *NSECAnswer {
+	if x, ok := x.GetData().(*Answer_Nsec); ok {
+		return x.Nsec
+	}
+	return nil
+}
+
+func (x *Answer) GetNsec3() *NSEC3Answer {
+	if x, ok := x.GetData().(*Answer_Nsec3); ok {
+		return x.Nsec3
+	}
+	return nil
+}
+
+func (x *Answer) GetNaptr() *NAPTRAnswer {
+	if x, ok := x.GetData().(*Answer_Naptr); ok {
+		return x.Naptr
+	}
+	return nil
+}
+
+func (x *Answer) GetSshfp() *SSHFPAnswer {
+	if x, ok := x.GetData().(*Answer_Sshfp); ok {
+		return x.Sshfp
+	}
+	return nil
+}
+
+func (x *Answer) GetUri() *URIAnswer {
+	if x, ok := x.GetData().(*Answer_Uri); ok {
+		return x.Uri
+	}
+	return nil
+}
+
+func (x *Answer) GetAfsdb() *AFSDBAnswer {
+	if x, ok := x.GetData().(*Answer_Afsdb); ok {
+		return x.Afsdb
+	}
+	return nil
+}
+
+func (x *Answer) GetCert() *CERTAnswer {
+	if x, ok := x.GetData().(*Answer_Cert); ok {
+		return x.Cert
+	}
+	return nil
+}
+
+func (x *Answer) GetGpos() *GPOSAnswer {
+	if x, ok := x.GetData().(*Answer_Gpos); ok {
+		return x.Gpos
+	}
+	return nil
+}
+
+func (x *Answer) GetHinfo() *HINFOAnswer {
+	if x, ok := x.GetData().(*Answer_Hinfo); ok {
+		return x.Hinfo
+	}
+	return nil
+}
+
+func (x *Answer) GetHip() *HIPAnswer {
+	if x, ok := x.GetData().(*Answer_Hip); ok {
+		return x.Hip
+	}
+	return nil
+}
+
+func (x *Answer) GetLoc() *LOCAnswer {
+	if x, ok := x.GetData().(*Answer_Loc); ok {
+		return x.Loc
+	}
+	return nil
+}
+
+func (x *Answer) GetMinfo() *MINFOAnswer {
+	if x, ok := x.GetData().(*Answer_Minfo); ok {
+		return x.Minfo
+	}
+	return nil
+}
+
+func (x *Answer) GetPx() *PXAnswer {
+	if x, ok := x.GetData().(*Answer_Px); ok {
+		return x.Px
+	}
+	return nil
+}
+
+func (x *Answer) GetRp() *RPAnswer {
+	if x, ok := x.GetData().(*Answer_Rp); ok {
+		return x.Rp
+	}
+	return nil
+}
+
+func (x *Answer) GetTalink() *TALINKAnswer {
+	if x, ok := x.GetData().(*Answer_Talink); ok {
+		return x.Talink
+	}
+	return nil
+}
+
+func (x *Answer) GetTkey() *TKEYAnswer {
+	if x, ok := x.GetData().(*Answer_Tkey); ok {
+		return x.Tkey
+	}
+	return nil
+}
+
+type isAnswer_Data interface {
+	isAnswer_Data()
+}
+
+type Answer_Answer struct {
+	Answer string `protobuf:"bytes,5,opt,name=answer,proto3,oneof"`
+}
+
+type Answer_Pref struct {
+	// MX, KX, RT and NID
+	Pref *PrefAnswer `protobuf:"bytes,6,opt,name=pref,proto3,oneof"`
+}
+
+type Answer_Soa struct {
+	Soa *SOAAnswer `protobuf:"bytes,7,opt,name=soa,proto3,oneof"`
+}
+
+type Answer_Srv struct {
+	Srv *SRVAnswer `protobuf:"bytes,8,opt,name=srv,proto3,oneof"`
+}
+
+type Answer_Svcb struct {
+	// SVCB and HTTPS
+	Svcb *SVCBAnswer `protobuf:"bytes,9,opt,name=svcb,proto3,oneof"`
+}
+
+type Answer_Dnskey struct {
+	// DNSKEY and CDNSKEY
+	Dnskey *DNSKEYAnswer `protobuf:"bytes,10,opt,name=dnskey,proto3,oneof"`
+}
+
+type Answer_Ds struct {
+	// DS and CDS
+	Ds *DSAnswer `protobuf:"bytes,11,opt,name=ds,proto3,oneof"`
+}
+
+type Answer_Rrsig struct {
+	// RRSIG and SIG
+	Rrsig *RRSIGAnswer `protobuf:"bytes,12,opt,name=rrsig,proto3,oneof"`
+}
+
+type Answer_Caa struct {
+	Caa *CAAAnswer `protobuf:"bytes,13,opt,name=caa,proto3,oneof"`
+}
+
+type Answer_Tlsa struct {
+	Tlsa *TLSAAnswer `protobuf:"bytes,14,opt,name=tlsa,proto3,oneof"`
+}
+
+type Answer_Smimea struct {
+	Smimea *SMIMEAAnswer `protobuf:"bytes,15,opt,name=smimea,proto3,oneof"`
+}
+
+type Answer_Nsec struct {
+	Nsec *NSECAnswer `protobuf:"bytes,16,opt,name=nsec,proto3,oneof"`
+}
+
+type Answer_Nsec3 struct {
+	// NSEC3 and NSEC3PARAM
+	Nsec3 *NSEC3Answer `protobuf:"bytes,17,opt,name=nsec3,proto3,oneof"`
+}
+
+type Answer_Naptr struct {
+	Naptr *NAPTRAnswer `protobuf:"bytes,18,opt,name=naptr,proto3,oneof"`
+}
+
+type Answer_Sshfp struct {
+	Sshfp *SSHFPAnswer `protobuf:"bytes,19,opt,name=sshfp,proto3,oneof"`
+}
+
+type Answer_Uri struct {
+	Uri *URIAnswer `protobuf:"bytes,20,opt,name=uri,proto3,oneof"`
+}
+
+type Answer_Afsdb struct {
+	Afsdb *AFSDBAnswer `protobuf:"bytes,21,opt,name=afsdb,proto3,oneof"`
+}
+
+type Answer_Cert struct {
+	Cert *CERTAnswer `protobuf:"bytes,22,opt,name=cert,proto3,oneof"`
+}
+
+type Answer_Gpos struct {
+	Gpos *GPOSAnswer `protobuf:"bytes,23,opt,name=gpos,proto3,oneof"`
+}
+
+type Answer_Hinfo struct {
+	Hinfo *HINFOAnswer `protobuf:"bytes,24,opt,name=hinfo,proto3,oneof"`
+}
+
+type Answer_Hip struct {
+	Hip *HIPAnswer `protobuf:"bytes,25,opt,name=hip,proto3,oneof"`
+}
+
+type Answer_Loc struct {
+	Loc *LOCAnswer `protobuf:"bytes,26,opt,name=loc,proto3,oneof"`
+}
+
+type Answer_Minfo struct {
+	Minfo *MINFOAnswer `protobuf:"bytes,27,opt,name=minfo,proto3,oneof"`
+}
+
+type Answer_Px struct {
+	Px *PXAnswer `protobuf:"bytes,28,opt,name=px,proto3,oneof"`
+}
+
+type Answer_Rp struct {
+	Rp *RPAnswer `protobuf:"bytes,29,opt,name=rp,proto3,oneof"`
+}
+
+type Answer_Talink struct {
+	Talink *TALINKAnswer `protobuf:"bytes,30,opt,name=talink,proto3,oneof"`
+}
+
+type Answer_Tkey struct {
+	Tkey *TKEYAnswer `protobuf:"bytes,31,opt,name=tkey,proto3,oneof"`
+}
+
+func (*Answer_Answer) isAnswer_Data() {}
+
+func (*Answer_Pref) isAnswer_Data() {}
+
+func (*Answer_Soa) isAnswer_Data() {}
+
+func (*Answer_Srv) isAnswer_Data() {}
+
+func (*Answer_Svcb) isAnswer_Data() {}
+
+func (*Answer_Dnskey) isAnswer_Data() {}
+
+func (*Answer_Ds) isAnswer_Data() {}
+
+func (*Answer_Rrsig) isAnswer_Data() {}
+
+func (*Answer_Caa) isAnswer_Data() {}
+
+func (*Answer_Tlsa) isAnswer_Data() {}
+
+func (*Answer_Smimea) isAnswer_Data() {}
+
+func (*Answer_Nsec) isAnswer_Data() {}
+
+func (*Answer_Nsec3) isAnswer_Data() {}
+
+func (*Answer_Naptr) isAnswer_Data() {}
+
+func (*Answer_Sshfp) isAnswer_Data() {}
+
+func (*Answer_Uri) isAnswer_Data() {}
+
+func (*Answer_Afsdb) isAnswer_Data() {}
+
+func (*Answer_Cert) isAnswer_Data() {}
+
+func (*Answer_Gpos) isAnswer_Data() {}
+
+func (*Answer_Hinfo) isAnswer_Data() {}
+
+func (*Answer_Hip) isAnswer_Data() {}
+
+func (*Answer_Loc) isAnswer_Data() {}
+
+func (*Answer_Minfo) isAnswer_Data() {}
+
+func (*Answer_Px) isAnswer_Data() {}
+
+func (*Answer_Rp) isAnswer_Data() {}
+
+func (*Answer_Talink) isAnswer_Data() {}
+
+func (*Answer_Tkey) isAnswer_Data() {}
+
+type PrefAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answer     string `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	Preference uint32 `protobuf:"varint,2,opt,name=preference,proto3" json:"preference,omitempty"`
+}
+
+func (x *PrefAnswer) Reset() {
+	*x = PrefAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefAnswer) ProtoMessage() {}
+
+func (x *PrefAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefAnswer.ProtoReflect.Descriptor instead.
+func (*PrefAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PrefAnswer) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *PrefAnswer) GetPreference() uint32 {
+	if x != nil {
+		return x.Preference
+	}
+	return 0
+}
+
+type SOAAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ns      string `protobuf:"bytes,1,opt,name=ns,proto3" json:"ns,omitempty"`
+	Mbox    string `protobuf:"bytes,2,opt,name=mbox,proto3" json:"mbox,omitempty"`
+	Serial  uint32 `protobuf:"varint,3,opt,name=serial,proto3" json:"serial,omitempty"`
+	Refresh uint32 `protobuf:"varint,4,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	Retry   uint32 `protobuf:"varint,5,opt,name=retry,proto3" json:"retry,omitempty"`
+	Expire  uint32 `protobuf:"varint,6,opt,name=expire,proto3" json:"expire,omitempty"`
+	MinTtl  uint32 `protobuf:"varint,7,opt,name=min_ttl,json=minTtl,proto3" json:"min_ttl,omitempty"`
+}
+
+func (x *SOAAnswer) Reset() {
+	*x = SOAAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SOAAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SOAAnswer) ProtoMessage() {}
+
+func (x *SOAAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SOAAnswer.ProtoReflect.Descriptor instead.
+func (*SOAAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SOAAnswer) GetNs() string {
+	if x != nil {
+		return x.Ns
+	}
+	return ""
+}
+
+func (x *SOAAnswer) GetMbox() string {
+	if x != nil {
+		return x.Mbox
+	}
+	return ""
+}
+
+func (x *SOAAnswer) GetSerial() uint32 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *SOAAnswer) GetRefresh() uint32 {
+	if x != nil {
+		return x.Refresh
+	}
+	return 0
+}
+
+func (x *SOAAnswer) GetRetry() uint32 {
+	if x != nil {
+		return x.Retry
+	}
+	return 0
+}
+
+func (x *SOAAnswer) GetExpire() uint32 {
+	if x != nil {
+		return x.Expire
+	}
+	return 0
+}
+
+func (x *SOAAnswer) GetMinTtl() uint32 {
+	if x != nil {
+		return x.MinTtl
+	}
+	return 0
+}
+
+type SRVAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority uint32 `protobuf:"varint,1,opt,name=priority,proto3" json:"priority,omitempty"`
+	Weight   uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Port     uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Target   string `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *SRVAnswer) Reset() {
+	*x = SRVAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SRVAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRVAnswer) ProtoMessage() {}
+
+func (x *SRVAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRVAnswer.ProtoReflect.Descriptor instead.
+func (*SRVAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SRVAnswer) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SRVAnswer) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *SRVAnswer) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *SRVAnswer) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type SVCBAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority  uint32           `protobuf:"varint,1,opt,name=priority,proto3" json:"priority,omitempty"`
+	Target    string           `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Svcparams *structpb.Struct `protobuf:"bytes,3,opt,name=svcparams,proto3" json:"svcparams,omitempty"`
+}
+
+func (x *SVCBAnswer) Reset() {
+	*x = SVCBAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SVCBAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SVCBAnswer) ProtoMessage() {}
+
+func (x *SVCBAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SVCBAnswer.ProtoReflect.Descriptor instead.
+func (*SVCBAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SVCBAnswer) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SVCBAnswer) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SVCBAnswer) GetSvcparams() *structpb.Struct {
+	if x != nil {
+		return x.Svcparams
+	}
+	return nil
+}
+
+type DNSKEYAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flags     uint32 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Protocol  uint32 `protobuf:"varint,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Algorithm uint32 `protobuf:"varint,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	PublicKey string `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (x *DNSKEYAnswer) Reset() {
+	*x = DNSKEYAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSKEYAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSKEYAnswer) ProtoMessage() {}
+
+func (x *DNSKEYAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSKEYAnswer.ProtoReflect.Descriptor instead.
+func (*DNSKEYAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DNSKEYAnswer) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *DNSKEYAnswer) GetProtocol() uint32 {
+	if x != nil {
+		return x.Protocol
+	}
+	return 0
+}
+
+func (x *DNSKEYAnswer) GetAlgorithm() uint32 {
+	if x != nil {
+		return x.Algorithm
+	}
+	return 0
+}
+
+func (x *DNSKEYAnswer) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+type DSAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyTag     uint32 `protobuf:"varint,1,opt,name=key_tag,json=keyTag,proto3" json:"key_tag,omitempty"`
+	Algorithm  uint32 `protobuf:"varint,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	DigestType uint32 `protobuf:"varint,3,opt,name=digest_type,json=digestType,proto3" json:"digest_type,omitempty"`
+	Digest     string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *DSAnswer) Reset() {
+	*x = DSAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DSAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DSAnswer) ProtoMessage() {}
+
+func (x *DSAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DSAnswer.ProtoReflect.Descriptor instead.
+func (*DSAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DSAnswer) GetKeyTag() uint32 {
+	if x != nil {
+		return x.KeyTag
+	}
+	return 0
+}
+
+func (x *DSAnswer) GetAlgorithm() uint32 {
+	if x != nil {
+		return x.Algorithm
+	}
+	return 0
+}
+
+func (x *DSAnswer) GetDigestType() uint32 {
+	if x != nil {
+		return x.DigestType
+	}
+	return 0
+}
+
+func (x *DSAnswer) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type RRSIGAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TypeCovered uint32 `protobuf:"varint,1,opt,name=type_covered,json=typeCovered,proto3" json:"type_covered,omitempty"`
+	Algorithm   uint32 `protobuf:"varint,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Labels      uint32 `protobuf:"varint,3,opt,name=labels,proto3" json:"labels,omitempty"`
+	OriginalTtl uint32 `protobuf:"varint,4,opt,name=original_ttl,json=originalTtl,proto3" json:"original_ttl,omitempty"`
+	Expiration  string `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Inception   string `protobuf:"bytes,6,opt,name=inception,proto3" json:"inception,omitempty"`
+	Keytag      uint32 `protobuf:"varint,7,opt,name=keytag,proto3" json:"keytag,omitempty"`
+	SignerName  string `protobuf:"bytes,8,opt,name=signer_name,json=signerName,proto3" json:"signer_name,omitempty"`
+	Signature   string `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *RRSIGAnswer) Reset() {
+	*x = RRSIGAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RRSIGAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RRSIGAnswer) ProtoMessage() {}
+
+func (x *RRSIGAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RRSIGAnswer.ProtoReflect.Descriptor instead.
+func (*RRSIGAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RRSIGAnswer) GetTypeCovered() uint32 {
+	if x != nil {
+		return x.TypeCovered
+	}
+	return 0
+}
+
+func (x *RRSIGAnswer) GetAlgorithm() uint32 {
+	if x != nil {
+		return x.Algorithm
+	}
+	return 0
+}
+
+func (x *RRSIGAnswer) GetLabels() uint32 {
+	if x != nil {
+		return x.Labels
+	}
+	return 0
+}
+
+func (x *RRSIGAnswer) GetOriginalTtl() uint32 {
+	if x != nil {
+		return x.OriginalTtl
+	}
+	return 0
+}
+
+func (x *RRSIGAnswer) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *RRSIGAnswer) GetInception() string {
+	if x != nil {
+		return x.Inception
+	}
+	return ""
+}
+
+func (x *RRSIGAnswer) GetKeytag() uint32 {
+	if x != nil {
+		return x.Keytag
+	}
+	return 0
+}
+
+func (x *RRSIGAnswer) GetSignerName() string {
+	if x != nil {
+		return x.SignerName
+	}
+	return ""
+}
+
+func (x *RRSIGAnswer) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type CAAAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag   string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Flag  uint32 `protobuf:"varint,3,opt,name=flag,proto3" json:"flag,omitempty"`
+}
+
+func (x *CAAAnswer) Reset() {
+	*x = CAAAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CAAAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CAAAnswer) ProtoMessage() {}
+
+func (x *CAAAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CAAAnswer.ProtoReflect.Descriptor instead.
+func (*CAAAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CAAAnswer) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *CAAAnswer) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CAAAnswer) GetFlag() uint32 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+type TLSAAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CertUsage    uint32 `protobuf:"varint,1,opt,name=cert_usage,json=certUsage,proto3" json:"cert_usage,omitempty"`
+	Selector     uint32 `protobuf:"varint,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	MatchingType uint32 `protobuf:"varint,3,opt,name=matching_type,json=matchingType,proto3" json:"matching_type,omitempty"`
+	Certificate  string `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *TLSAAnswer) Reset() {
+	*x = TLSAAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TLSAAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLSAAnswer) ProtoMessage() {}
+
+func (x *TLSAAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLSAAnswer.ProtoReflect.Descriptor instead.
+func (*TLSAAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TLSAAnswer) GetCertUsage() uint32 {
+	if x != nil {
+		return x.CertUsage
+	}
+	return 0
+}
+
+func (x *TLSAAnswer) GetSelector() uint32 {
+	if x != nil {
+		return x.Selector
+	}
+	return 0
+}
+
+func (x *TLSAAnswer) GetMatchingType() uint32 {
+	if x != nil {
+		return x.MatchingType
+	}
+	return 0
+}
+
+func (x *TLSAAnswer) GetCertificate() string {
+	if x != nil {
+		return x.Certificate
+	}
+	return ""
+}
+
+type SMIMEAAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Usage        uint32 `protobuf:"varint,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	Selector     uint32 `protobuf:"varint,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	MatchingType uint32 `protobuf:"varint,3,opt,name=matching_type,json=matchingType,proto3" json:"matching_type,omitempty"`
+	Certificate  string `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *SMIMEAAnswer) Reset() {
+	*x = SMIMEAAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SMIMEAAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMIMEAAnswer) ProtoMessage() {}
+
+func (x *SMIMEAAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMIMEAAnswer.ProtoReflect.Descriptor instead.
+func (*SMIMEAAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SMIMEAAnswer) GetUsage() uint32 {
+	if x != nil {
+		return x.Usage
+	}
+	return 0
+}
+
+func (x *SMIMEAAnswer) GetSelector() uint32 {
+	if x != nil {
+		return x.Selector
+	}
+	return 0
+}
+
+func (x *SMIMEAAnswer) GetMatchingType() uint32 {
+	if x != nil {
+		return x.MatchingType
+	}
+	return 0
+}
+
+func (x *SMIMEAAnswer) GetCertificate() string {
+	if x != nil {
+		return x.Certificate
+	}
+	return ""
+}
+
+type NSECAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextDomain string `protobuf:"bytes,1,opt,name=next_domain,json=nextDomain,proto3" json:"next_domain,omitempty"`
+	TypeBitMap string `protobuf:"bytes,2,opt,name=type_bit_map,json=typeBitMap,proto3" json:"type_bit_map,omitempty"`
+}
+
+func (x *NSECAnswer) Reset() {
+	*x = NSECAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NSECAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NSECAnswer) ProtoMessage() {}
+
+func (x *NSECAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NSECAnswer.ProtoReflect.Descriptor instead.
+func (*NSECAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NSECAnswer) GetNextDomain() string {
+	if x != nil {
+		return x.NextDomain
+	}
+	return ""
+}
+
+func (x *NSECAnswer) GetTypeBitMap() string {
+	if x != nil {
+		return x.TypeBitMap
+	}
+	return ""
+}
+
+type NSEC3Answer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HashAlgorithm uint32 `protobuf:"varint,1,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hash_algorithm,omitempty"`
+	Flags         uint32 `protobuf:"varint,2,opt,name=flags,proto3" json:"flags,omitempty"`
+	Iterations    uint32 `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Salt          string `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+}
+
+func (x *NSEC3Answer) Reset() {
+	*x = NSEC3Answer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NSEC3Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NSEC3Answer) ProtoMessage() {}
+
+func (x *NSEC3Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NSEC3Answer.ProtoReflect.Descriptor instead.
+func (*NSEC3Answer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NSEC3Answer) GetHashAlgorithm() uint32 {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return 0
+}
+
+func (x *NSEC3Answer) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *NSEC3Answer) GetIterations() uint32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *NSEC3Answer) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+type NAPTRAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order       uint32 `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
+	Preference  uint32 `protobuf:"varint,2,opt,name=preference,proto3" json:"preference,omitempty"`
+	Flags       string `protobuf:"bytes,3,opt,name=flags,proto3" json:"flags,omitempty"`
+	Service     string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	Regexp      string `protobuf:"bytes,5,opt,name=regexp,proto3" json:"regexp,omitempty"`
+	Replacement string `protobuf:"bytes,6,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (x *NAPTRAnswer) Reset() {
+	*x = NAPTRAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NAPTRAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NAPTRAnswer) ProtoMessage() {}
+
+func (x *NAPTRAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NAPTRAnswer.ProtoReflect.Descriptor instead.
+func (*NAPTRAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NAPTRAnswer) GetOrder() uint32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *NAPTRAnswer) GetPreference() uint32 {
+	if x != nil {
+		return x.Preference
+	}
+	return 0
+}
+
+func (x *NAPTRAnswer) GetFlags() string {
+	if x != nil {
+		return x.Flags
+	}
+	return ""
+}
+
+func (x *NAPTRAnswer) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *NAPTRAnswer) GetRegexp() string {
+	if x != nil {
+		return x.Regexp
+	}
+	return ""
+}
+
+func (x *NAPTRAnswer) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+type SSHFPAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm   uint32 `protobuf:"varint,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Type        uint32 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Fingerprint string `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+}
+
+func (x *SSHFPAnswer) Reset() {
+	*x = SSHFPAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SSHFPAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHFPAnswer) ProtoMessage() {}
+
+func (x *SSHFPAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHFPAnswer.ProtoReflect.Descriptor instead.
+func (*SSHFPAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SSHFPAnswer) GetAlgorithm() uint32 {
+	if x != nil {
+		return x.Algorithm
+	}
+	return 0
+}
+
+func (x *SSHFPAnswer) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *SSHFPAnswer) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+type URIAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority uint32 `protobuf:"varint,1,opt,name=priority,proto3" json:"priority,omitempty"`
+	Weight   uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Target   string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *URIAnswer) Reset() {
+	*x = URIAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *URIAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URIAnswer) ProtoMessage() {}
+
+func (x *URIAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URIAnswer.ProtoReflect.Descriptor instead.
+func (*URIAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *URIAnswer) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *URIAnswer) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *URIAnswer) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type AFSDBAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subtype  uint32 `protobuf:"varint,1,opt,name=subtype,proto3" json:"subtype,omitempty"`
+	Hostname string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (x *AFSDBAnswer) Reset() {
+	*x = AFSDBAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AFSDBAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AFSDBAnswer) ProtoMessage() {}
+
+func (x *AFSDBAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AFSDBAnswer.ProtoReflect.Descriptor instead.
+func (*AFSDBAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AFSDBAnswer) GetSubtype() uint32 {
+	if x != nil {
+		return x.Subtype
+	}
+	return 0
+}
+
+func (x *AFSDBAnswer) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type CERTAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Keytag      uint32 `protobuf:"varint,2,opt,name=keytag,proto3" json:"keytag,omitempty"`
+	Algorithm   string `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Certificate string `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *CERTAnswer) Reset() {
+	*x = CERTAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CERTAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CERTAnswer) ProtoMessage() {}
+
+func (x *CERTAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CERTAnswer.ProtoReflect.Descriptor instead.
+func (*CERTAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CERTAnswer) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CERTAnswer) GetKeytag() uint32 {
+	if x != nil {
+		return x.Keytag
+	}
+	return 0
+}
+
+func (x *CERTAnswer) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *CERTAnswer) GetCertificate() string {
+	if x != nil {
+		return x.Certificate
+	}
+	return ""
+}
+
+type GPOSAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the JSON output uses these names for the fields of GPOS
+	Longitude string `protobuf:"bytes,1,opt,name=longitude,json=preference,proto3" json:"longitude,omitempty"`
+	Latitude  string `protobuf:"bytes,2,opt,name=latitude,json=map822,proto3" json:"latitude,omitempty"`
+	Altitude  string `protobuf:"bytes,3,opt,name=altitude,json=mapx400,proto3" json:"altitude,omitempty"`
+}
+
+func (x *GPOSAnswer) Reset() {
+	*x = GPOSAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GPOSAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GPOSAnswer) ProtoMessage() {}
+
+func (x *GPOSAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GPOSAnswer.ProtoReflect.Descriptor instead.
+func (*GPOSAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GPOSAnswer) GetLongitude() string {
+	if x != nil {
+		return x.Longitude
+	}
+	return ""
+}
+
+func (x *GPOSAnswer) GetLatitude() string {
+	if x != nil {
+		return x.Latitude
+	}
+	return ""
+}
+
+func (x *GPOSAnswer) GetAltitude() string {
+	if x != nil {
+		return x.Altitude
+	}
+	return ""
+}
+
+type HINFOAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cpu string `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Os  string `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+}
+
+func (x *HINFOAnswer) Reset() {
+	*x = HINFOAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HINFOAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HINFOAnswer) ProtoMessage() {}
+
+func (x *HINFOAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HINFOAnswer.ProtoReflect.Descriptor instead.
+func (*HINFOAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *HINFOAnswer) GetCpu() string {
+	if x != nil {
+		return x.Cpu
+	}
+	return ""
+}
+
+func (x *HINFOAnswer) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+type HIPAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HitLength         uint32   `protobuf:"varint,1,opt,name=hit_length,json=hitLength,proto3" json:"hit_length,omitempty"`
+	PubkeyAlgo        uint32   `protobuf:"varint,2,opt,name=pubkey_algo,json=pubkeyAlgo,proto3" json:"pubkey_algo,omitempty"`
+	PubkeyLen         uint32   `protobuf:"varint,3,opt,name=pubkey_len,json=pubkeyLen,proto3" json:"pubkey_len,omitempty"`
+	Hit               string   `protobuf:"bytes,4,opt,name=hit,proto3" json:"hit,omitempty"`
+	Pubkey            string   `protobuf:"bytes,5,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	RendezvousServers []string `protobuf:"bytes,6,rep,name=rendezvous_servers,json=rendezvousServers,proto3" json:"rendezvous_servers,omitempty"`
+}
+
+func (x *HIPAnswer) Reset() {
+	*x = HIPAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HIPAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HIPAnswer) ProtoMessage() {}
+
+func (x *HIPAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HIPAnswer.ProtoReflect.Descriptor instead.
+func (*HIPAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *HIPAnswer) GetHitLength() uint32 {
+	if x != nil {
+		return x.HitLength
+	}
+	return 0
+}
+
+func (x *HIPAnswer) GetPubkeyAlgo() uint32 {
+	if x != nil {
+		return x.PubkeyAlgo
+	}
+	return 0
+}
+
+func (x *HIPAnswer) GetPubkeyLen() uint32 {
+	if x != nil {
+		return x.PubkeyLen
+	}
+	return 0
+}
+
+func (x *HIPAnswer) GetHit() string {
+	if x != nil {
+		return x.Hit
+	}
+	return ""
+}
+
+func (x *HIPAnswer) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+func (x *HIPAnswer) GetRendezvousServers() []string {
+	if x != nil {
+		return x.RendezvousServers
+	}
+	return nil
+}
+
+type LOCAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version       uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Size          uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	HorizontalPre uint32 `protobuf:"varint,3,opt,name=horizontal_pre,json=horizontalPre,proto3" json:"horizontal_pre,omitempty"`
+	VerticalPre   uint32 `protobuf:"varint,4,opt,name=vertical_pre,json=verticalPre,proto3" json:"vertical_pre,omitempty"`
+	Latitude      uint32 `protobuf:"varint,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     uint32 `protobuf:"varint,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      uint32 `protobuf:"varint,7,opt,name=altitude,proto3" json:"altitude,omitempty"`
+}
+
+func (x *LOCAnswer) Reset() {
+	*x = LOCAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LOCAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LOCAnswer) ProtoMessage() {}
+
+func (x *LOCAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LOCAnswer.ProtoReflect.Descriptor instead.
+func (*LOCAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LOCAnswer) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetHorizontalPre() uint32 {
+	if x != nil {
+		return x.HorizontalPre
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetVerticalPre() uint32 {
+	if x != nil {
+		return x.VerticalPre
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetLatitude() uint32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetLongitude() uint32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *LOCAnswer) GetAltitude() uint32 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+type MINFOAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rmail string `protobuf:"bytes,1,opt,name=rmail,proto3" json:"rmail,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *MINFOAnswer) Reset() {
+	*x = MINFOAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MINFOAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MINFOAnswer) ProtoMessage() {}
+
+func (x *MINFOAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MINFOAnswer.ProtoReflect.Descriptor instead.
+func (*MINFOAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MINFOAnswer) GetRmail() string {
+	if x != nil {
+		return x.Rmail
+	}
+	return ""
+}
+
+func (x *MINFOAnswer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type PXAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Preference uint32 `protobuf:"varint,1,opt,name=preference,proto3" json:"preference,omitempty"`
+	Map822     string `protobuf:"bytes,2,opt,name=map822,proto3" json:"map822,omitempty"`
+	Mapx400    string `protobuf:"bytes,3,opt,name=mapx400,proto3" json:"mapx400,omitempty"`
+}
+
+func (x *PXAnswer) Reset() {
+	*x = PXAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PXAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PXAnswer) ProtoMessage() {}
+
+func (x *PXAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PXAnswer.ProtoReflect.Descriptor instead.
+func (*PXAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PXAnswer) GetPreference() uint32 {
+	if x != nil {
+		return x.Preference
+	}
+	return 0
+}
+
+func (x *PXAnswer) GetMap822() string {
+	if x != nil {
+		return x.Map822
+	}
+	return ""
+}
+
+func (x *PXAnswer) GetMapx400() string {
+	if x != nil {
+		return x.Mapx400
+	}
+	return ""
+}
+
+type RPAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mbox string `protobuf:"bytes,1,opt,name=mbox,proto3" json:"mbox,omitempty"`
+	Txt  string `protobuf:"bytes,2,opt,name=txt,proto3" json:"txt,omitempty"`
+}
+
+func (x *RPAnswer) Reset() {
+	*x = RPAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RPAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPAnswer) ProtoMessage() {}
+
+func (x *RPAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPAnswer.ProtoReflect.Descriptor instead.
+func (*RPAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RPAnswer) GetMbox() string {
+	if x != nil {
+		return x.Mbox
+	}
+	return ""
+}
+
+func (x *RPAnswer) GetTxt() string {
+	if x != nil {
+		return x.Txt
+	}
+	return ""
+}
+
+type TALINKAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PreviousName string `protobuf:"bytes,1,opt,name=previous_name,json=previousName,proto3" json:"previous_name,omitempty"`
+	NextName     string `protobuf:"bytes,2,opt,name=next_name,json=nextName,proto3" json:"next_name,omitempty"`
+}
+
+func (x *TALINKAnswer) Reset() {
+	*x = TALINKAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TALINKAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TALINKAnswer) ProtoMessage() {}
+
+func (x *TALINKAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TALINKAnswer.ProtoReflect.Descriptor instead.
+func (*TALINKAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TALINKAnswer) GetPreviousName() string {
+	if x != nil {
+		return x.PreviousName
+	}
+	return ""
+}
+
+func (x *TALINKAnswer) GetNextName() string {
+	if x != nil {
+		return x.NextName
+	}
+	return ""
+}
+
+type TKEYAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm  string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Inception  string `protobuf:"bytes,2,opt,name=inception,proto3" json:"inception,omitempty"`
+	Expiration string `protobuf:"bytes,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Mode       uint32 `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	Error      uint32 `protobuf:"varint,5,opt,name=error,proto3" json:"error,omitempty"`
+	KeySize    uint32 `protobuf:"varint,6,opt,name=key_size,json=keySize,proto3" json:"key_size,omitempty"`
+	Key        string `protobuf:"bytes,7,opt,name=key,proto3" json:"key,omitempty"`
+	OtherLen   uint32 `protobuf:"varint,8,opt,name=other_len,json=otherLen,proto3" json:"other_len,omitempty"`
+	OtherData  string `protobuf:"bytes,9,opt,name=other_data,json=otherData,proto3" json:"other_data,omitempty"`
+}
+
+func (x *TKEYAnswer) Reset() {
+	*x = TKEYAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zdns_result_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TKEYAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TKEYAnswer) ProtoMessage() {}
+
+func (x *TKEYAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_zdns_result_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TKEYAnswer.ProtoReflect.Descriptor instead.
+func (*TKEYAnswer) Descriptor() ([]byte, []int) {
+	return file_zdns_result_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TKEYAnswer) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *TKEYAnswer) GetInception() string {
+	if x != nil {
+		return x.Inception
+	}
+	return ""
+}
+
+func (x *TKEYAnswer) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *TKEYAnswer) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *TKEYAnswer) GetError() uint32 {
+	if x != nil {
+		return x.Error
+	}
+	return 0
+}
+
+func (x *TKEYAnswer) GetKeySize() uint32 {
+	if x != nil {
+		return x.KeySize
+	}
+	return 0
+}
+
+func (x *TKEYAnswer) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TKEYAnswer) GetOtherLen() uint32 {
+	if x != nil {
+		return x.OtherLen
+	}
+	return 0
+}
+
+func (x *TKEYAnswer) GetOtherData() string {
+	if x != nil {
+		return x.OtherData
+	}
+	return ""
+}
+
+var File_zdns_result_proto protoreflect.FileDescriptor
+
+var file_zdns_result_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x7a, 0x64, 0x6e, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x07, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x05, 0x0a, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6c, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6c, 0x65, 0x78, 0x61, 0x5f, 0x72, 0x61, 0x6e, 0x6b,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x6c, 0x65, 0x78, 0x61, 0x52, 0x61, 0x6e,
+	0x6b, 0x12, 0x32, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x26, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x48, 0x00, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x02, 0x69, 0x70, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x50, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x02, 0x69, 0x70, 0x12, 0x23,
+	0x0a, 0x02, 0x6d, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64, 0x6e,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x58, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52,
+	0x02, 0x6d, 0x78, 0x12, 0x23, 0x0a, 0x02, 0x6e, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x53, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
+	0x00, 0x52, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x10, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x4b, 0x0a, 0x0c, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a, 0x64,
+	0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0xfd, 0x01, 0x0a, 0x09, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a,
+	0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x0b,
+	0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x73, 0x12, 0x31, 0x0a, 0x0b, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x4e, 0x53, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22,
+	0xd2, 0x02, 0x0a, 0x08, 0x44, 0x4e, 0x53, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x70, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x10, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65,
+	0x64, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x22, 0xdc, 0x01, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74,
+	0x65, 0x70, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4e,
+	0x53, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x22, 0x58, 0x0a, 0x08, 0x49, 0x50, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x3b, 0x0a,
+	0x08, 0x4d, 0x58, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x09, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a,
+	0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x58, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x09, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x08, 0x4d,
+	0x58, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69,
+	0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x37, 0x0a, 0x08, 0x4e, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x53, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x92,
+	0x01, 0x0a, 0x08, 0x4e, 0x53, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x70, 0x76,
+	0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x70,
+	0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x74, 0x74, 0x6c, 0x22, 0xdb, 0x09, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x74, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x18,
+	0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x04, 0x70, 0x72, 0x65, 0x66,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x65, 0x66, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x70,
+	0x72, 0x65, 0x66, 0x12, 0x26, 0x0a, 0x03, 0x73, 0x6f, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4f, 0x41, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03, 0x73, 0x6f, 0x61, 0x12, 0x26, 0x0a, 0x03, 0x73,
+	0x72, 0x76, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x52, 0x56, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03,
+	0x73, 0x72, 0x76, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x76, 0x63, 0x62, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x56, 0x43, 0x42,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x73, 0x76, 0x63, 0x62, 0x12, 0x2f,
+	0x0a, 0x06, 0x64, 0x6e, 0x73, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4e, 0x53, 0x4b, 0x45, 0x59, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x64, 0x6e, 0x73, 0x6b, 0x65, 0x79, 0x12,
+	0x23, 0x0a, 0x02, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64,
+	0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x53, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00,
+	0x52, 0x02, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x72, 0x73, 0x69, 0x67, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x52,
+	0x53, 0x49, 0x47, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x72, 0x72, 0x73,
+	0x69, 0x67, 0x12, 0x26, 0x0a, 0x03, 0x63, 0x61, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x41, 0x41, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03, 0x63, 0x61, 0x61, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x6c,
+	0x73, 0x61, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x41, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x04, 0x74, 0x6c, 0x73, 0x61, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x6d, 0x69, 0x6d, 0x65, 0x61, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x4d, 0x49, 0x4d, 0x45, 0x41, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x6d, 0x69, 0x6d, 0x65, 0x61, 0x12, 0x29, 0x0a, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x53, 0x45, 0x43, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x73, 0x65,
+	0x63, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x73, 0x65, 0x63, 0x33, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x53, 0x45, 0x43, 0x33,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x6e, 0x73, 0x65, 0x63, 0x33, 0x12,
+	0x2c, 0x0a, 0x05, 0x6e, 0x61, 0x70, 0x74, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x41, 0x50, 0x54, 0x52, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x6e, 0x61, 0x70, 0x74, 0x72, 0x12, 0x2c, 0x0a,
+	0x05, 0x73, 0x73, 0x68, 0x66, 0x70, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a,
+	0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x53, 0x48, 0x46, 0x50, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x73, 0x73, 0x68, 0x66, 0x70, 0x12, 0x26, 0x0a, 0x03, 0x75,
+	0x72, 0x69, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x52, 0x49, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03,
+	0x75, 0x72, 0x69, 0x12, 0x2c, 0x0a, 0x05, 0x61, 0x66, 0x73, 0x64, 0x62, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x46, 0x53,
+	0x44, 0x42, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x61, 0x66, 0x73, 0x64,
+	0x62, 0x12, 0x29, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x45, 0x52, 0x54, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x29, 0x0a, 0x04,
+	0x67, 0x70, 0x6f, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x64, 0x6e,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x50, 0x4f, 0x53, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x04, 0x67, 0x70, 0x6f, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x68, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x49, 0x4e, 0x46, 0x4f, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05,
+	0x68, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x03, 0x68, 0x69, 0x70, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x49, 0x50,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03, 0x68, 0x69, 0x70, 0x12, 0x26, 0x0a,
+	0x03, 0x6c, 0x6f, 0x63, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x64, 0x6e,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4f, 0x43, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00,
+	0x52, 0x03, 0x6c, 0x6f, 0x63, 0x12, 0x2c, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x1b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x49, 0x4e, 0x46, 0x4f, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x69,
+	0x6e, 0x66, 0x6f, 0x12, 0x23, 0x0a, 0x02, 0x70, 0x78, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x58, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x48, 0x00, 0x52, 0x02, 0x70, 0x78, 0x12, 0x23, 0x0a, 0x02, 0x72, 0x70, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x50, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x02, 0x72, 0x70, 0x12, 0x2f, 0x0a,
+	0x06, 0x74, 0x61, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x7a, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x41, 0x4c, 0x49, 0x4e, 0x4b, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x74, 0x61, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x29,
+	0x0a, 0x04, 0x74, 0x6b, 0x65, 0x79, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a,
+	0x64, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4b, 0x45, 0x59, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x04, 0x74, 0x6b, 0x65, 0x79, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x44, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x66, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x09, 0x53, 0x4f, 0x41, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x62, 0x6f, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x62, 0x6f, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e,
+	0x5f, 0x74, 0x74, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x54,
+	0x74, 0x6c, 0x22, 0x6b, 0x0a, 0x09, 0x53, 0x52, 0x56, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
+	0x77, 0x0a, 0x0a, 0x53, 0x56, 0x43, 0x42, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x09, 0x73,
+	0x76, 0x63, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x7d, 0x0a, 0x0c, 0x44, 0x4e, 0x53, 0x4b,
+	0x45, 0x59, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x7a, 0x0a, 0x08, 0x44, 0x53, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x22, 0x9e, 0x02, 0x0a, 0x0b, 0x52, 0x52, 0x53, 0x49, 0x47, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x54, 0x74, 0x6c, 0x12,
+	0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x6b, 0x65, 0x79, 0x74, 0x61, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6b,
+	0x65, 0x79, 0x74, 0x61, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0x47, 0x0a, 0x09, 0x43, 0x41, 0x41, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x22, 0x8e, 0x01,
+	0x0a, 0x0a, 0x54, 0x4c, 0x53, 0x41, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x65, 0x72, 0x74, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x63, 0x65, 0x72, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x69, 0x6e, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x87,
+	0x01, 0x0a, 0x0c, 0x53, 0x4d, 0x49, 0x4d, 0x45, 0x41, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69,
+	0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x4f, 0x0a, 0x0a, 0x4e, 0x53, 0x45, 0x43,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78,
+	0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x62, 0x69, 0x74, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x79, 0x70, 0x65, 0x42, 0x69, 0x74, 0x4d, 0x61, 0x70, 0x22, 0x7e, 0x0a, 0x0b, 0x4e, 0x53, 0x45,
+	0x43, 0x33, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68,
+	0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x22, 0xad, 0x01, 0x0a, 0x0b, 0x4e, 0x41,
+	0x50, 0x54, 0x52, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x65, 0x78, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x67, 0x65, 0x78, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x0b, 0x53, 0x53, 0x48,
+	0x46, 0x50, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x57, 0x0a, 0x09,
+	0x55, 0x52, 0x49, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x43, 0x0a, 0x0b, 0x41, 0x46, 0x53, 0x44, 0x42, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x75, 0x62, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x78, 0x0a, 0x0a, 0x43, 0x45,
+	0x52, 0x54, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6b, 0x65, 0x79, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6b, 0x65,
+	0x79, 0x74, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x22, 0x60, 0x0a, 0x0a, 0x47, 0x50, 0x4f, 0x53, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x18, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x70, 0x38, 0x32, 0x32, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x61, 0x70, 0x78, 0x34, 0x30, 0x30, 0x22, 0x2f, 0x0a, 0x0b, 0x48, 0x49, 0x4e, 0x46, 0x4f, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x09, 0x48, 0x49, 0x50, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x69, 0x74, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x68, 0x69, 0x74, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x5f, 0x61,
+	0x6c, 0x67, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6b, 0x65,
+	0x79, 0x41, 0x6c, 0x67, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x5f,
+	0x6c, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6b, 0x65,
+	0x79, 0x4c, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x68, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2d,
+	0x0a, 0x12, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x7a, 0x76, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6e, 0x64,
+	0x65, 0x7a, 0x76, 0x6f, 0x75, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0xd9, 0x01,
+	0x0a, 0x09, 0x4c, 0x4f, 0x43, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x50, 0x72, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x50, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x39, 0x0a, 0x0b, 0x4d, 0x49, 0x4e,
+	0x46, 0x4f, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x22, 0x5c, 0x0a, 0x08, 0x50, 0x58, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x38, 0x32, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x61, 0x70, 0x38, 0x32, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x70, 0x78,
+	0x34, 0x30, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x78, 0x34,
+	0x30, 0x30, 0x22, 0x30, 0x0a, 0x08, 0x52, 0x50, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x62, 0x6f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x62,
+	0x6f, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x74, 0x78, 0x74, 0x22, 0x50, 0x0a, 0x0c, 0x54, 0x41, 0x4c, 0x49, 0x4e, 0x4b, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65,
+	0x78, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xfb, 0x01, 0x0a, 0x0a, 0x54, 0x4b, 0x45, 0x59, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6b,
+	0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6b,
+	0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6f, 0x74, 0x68,
+	0x65, 0x72, 0x4c, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x7a, 0x6d, 0x61, 0x70, 0x2f, 0x7a, 0x64, 0x6e, 0x73, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x7a, 0x64, 0x6e, 0x73, 0x2f, 0x7a, 0x64, 0x6e, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x3b,
+	0x7a, 0x64, 0x6e, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_zdns_result_proto_rawDescOnce sync.Once
+	file_zdns_result_proto_rawDescData = file_zdns_result_proto_rawDesc
+)
+
+func file_zdns_result_proto_rawDescGZIP() []byte {
+	file_zdns_result_proto_rawDescOnce.Do(func() {
+		file_zdns_result_proto_rawDescData = protoimpl.X.CompressGZIP(file_zdns_result_proto_rawDescData)
+	})
+	return file_zdns_result_proto_rawDescData
+}
+
+var file_zdns_result_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_zdns_result_proto_goTypes = []interface{}{
+	(*Result)(nil),          // 0: zdns.v1.Result
+	(*DNSResult)(nil),       // 1: zdns.v1.DNSResult
+	(*DNSFlags)(nil),        // 2: zdns.v1.DNSFlags
+	(*TraceStep)(nil),       // 3: zdns.v1.TraceStep
+	(*IPResult)(nil),        // 4: zdns.v1.IPResult
+	(*MXResult)(nil),        // 5: zdns.v1.MXResult
+	(*MXRecord)(nil),        // 6: zdns.v1.MXRecord
+	(*NSResult)(nil),        // 7: zdns.v1.NSResult
+	(*NSRecord)(nil),        // 8: zdns.v1.NSRecord
+	(*Answer)(nil),          // 9: zdns.v1.Answer
+	(*PrefAnswer)(nil),      // 10: zdns.v1.PrefAnswer
+	(*SOAAnswer)(nil),       // 11: zdns.v1.SOAAnswer
+	(*SRVAnswer)(nil),       // 12: zdns.v1.SRVAnswer
+	(*SVCBAnswer)(nil),      // 13: zdns.v1.SVCBAnswer
+	(*DNSKEYAnswer)(nil),    // 14: zdns.v1.DNSKEYAnswer
+	(*DSAnswer)(nil),        // 15: zdns.v1.DSAnswer
+	(*RRSIGAnswer)(nil),     // 16: zdns.v1.RRSIGAnswer
+	(*CAAAnswer)(nil),       // 17: zdns.v1.CAAAnswer
+	(*TLSAAnswer)(nil),      // 18: zdns.v1.TLSAAnswer
+	(*SMIMEAAnswer)(nil),    // 19: zdns.v1.SMIMEAAnswer
+	(*NSECAnswer)(nil),      // 20: zdns.v1.NSECAnswer
+	(*NSEC3Answer)(nil),     // 21: zdns.v1.NSEC3Answer
+	(*NAPTRAnswer)(nil),     // 22: zdns.v1.NAPTRAnswer
+	(*SSHFPAnswer)(nil),     // 23: zdns.v1.SSHFPAnswer
+	(*URIAnswer)(nil),       // 24: zdns.v1.URIAnswer
+	(*AFSDBAnswer)(nil),     // 25: zdns.v1.AFSDBAnswer
+	(*CERTAnswer)(nil),      // 26: zdns.v1.CERTAnswer
+	(*GPOSAnswer)(nil),      // 27: zdns.v1.GPOSAnswer
+	(*HINFOAnswer)(nil),     // 28: zdns.v1.HINFOAnswer
+	(*HIPAnswer)(nil),       // 29: zdns.v1.HIPAnswer
+	(*LOCAnswer)(nil),       // 30: zdns.v1.LOCAnswer
+	(*MINFOAnswer)(nil),     // 31: zdns.v1.MINFOAnswer
+	(*PXAnswer)(nil),        // 32: zdns.v1.PXAnswer
+	(*RPAnswer)(nil),        // 33: zdns.v1.RPAnswer
+	(*TALINKAnswer)(nil),    // 34: zdns.v1.TALINKAnswer
+	(*TKEYAnswer)(nil),      // 35: zdns.v1.TKEYAnswer
+	nil,                     // 36: zdns.v1.Result.ResultsEntry
+	(*structpb.Value)(nil),  // 37: google.protobuf.Value
+	(*structpb.Struct)(nil), // 38: google.protobuf.Struct
+}
+var file_zdns_result_proto_depIdxs = []int32{
+	37, // 0: zdns.v1.Result.metadata:type_name -> google.protobuf.Value
+	1,  // 1: zdns.v1.Result.dns:type_name -> zdns.v1.DNSResult
+	4,  // 2: zdns.v1.Result.ip:type_name -> zdns.v1.IPResult
+	5,  // 3: zdns.v1.Result.mx:type_name -> zdns.v1.MXResult
+	7,  // 4: zdns.v1.Result.ns:type_name -> zdns.v1.NSResult
+	37, // 5: zdns.v1.Result.other:type_name -> google.protobuf.Value
+	3,  // 6: zdns.v1.Result.trace:type_name -> zdns.v1.TraceStep
+	36, // 7: zdns.v1.Result.results:type_name -> zdns.v1.Result.ResultsEntry
+	9,  // 8: zdns.v1.DNSResult.answers:type_name -> zdns.v1.Answer
+	9,  // 9: zdns.v1.DNSResult.additionals:type_name -> zdns.v1.Answer
+	9,  // 10: zdns.v1.DNSResult.authorities:type_name -> zdns.v1.Answer
+	2,  // 11: zdns.v1.DNSResult.flags:type_name -> zdns.v1.DNSFlags
+	1,  // 12: zdns.v1.TraceStep.results:type_name -> zdns.v1.DNSResult
+	6,  // 13: zdns.v1.MXResult.exchanges:type_name -> zdns.v1.MXRecord
+	8,  // 14: zdns.v1.NSResult.servers:type_name -> zdns.v1.NSRecord
+	10, // 15: zdns.v1.Answer.pref:type_name -> zdns.v1.PrefAnswer
+	11, // 16: zdns.v1.Answer.soa:type_name -> zdns.v1.SOAAnswer
+	12, // 17: zdns.v1.Answer.srv:type_name -> zdns.v1.SRVAnswer
+	13, // 18: zdns.v1.Answer.svcb:type_name -> zdns.v1.SVCBAnswer
+	14, // 19: zdns.v1.Answer.dnskey:type_name -> zdns.v1.DNSKEYAnswer
+	15, // 20: zdns.v1.Answer.ds:type_name -> zdns.v1.DSAnswer
+	16, // 21: zdns.v1.Answer.rrsig:type_name -> zdns.v1.RRSIGAnswer
+	17, // 22: zdns.v1.Answer.caa:type_name -> zdns.v1.CAAAnswer
+	18, // 23: zdns.v1.Answer.tlsa:type_name -> zdns.v1.TLSAAnswer
+	19, // 24: zdns.v1.Answer.smimea:type_name -> zdns.v1.SMIMEAAnswer
+	20, // 25: zdns.v1.Answer.nsec:type_name -> zdns.v1.NSECAnswer
+	21, // 26: zdns.v1.Answer.nsec3:type_name -> zdns.v1.NSEC3Answer
+	22, // 27: zdns.v1.Answer.naptr:type_name -> zdns.v1.NAPTRAnswer
+	23, // 28: zdns.v1.Answer.sshfp:type_name -> zdns.v1.SSHFPAnswer
+	24, // 29: zdns.v1.Answer.uri:type_name -> zdns.v1.URIAnswer
+	25, // 30: zdns.v1.Answer.afsdb:type_name -> zdns.v1.AFSDBAnswer
+	26, // 31: zdns.v1.Answer.cert:type_name -> zdns.v1.CERTAnswer
+	27, // 32: zdns.v1.Answer.gpos:type_name -> zdns.v1.GPOSAnswer
+	28, // 33: zdns.v1.Answer.hinfo:type_name -> zdns.v1.HINFOAnswer
+	29, // 34: zdns.v1.Answer.hip:type_name -> zdns.v1.HIPAnswer
+	30, // 35: zdns.v1.Answer.loc:type_name -> zdns.v1.LOCAnswer
+	31, // 36: zdns.v1.Answer.minfo:type_name -> zdns.v1.MINFOAnswer
+	32, // 37: zdns.v1.Answer.px:type_name -> zdns.v1.PXAnswer
+	33, // 38: zdns.v1.Answer.rp:type_name -> zdns.v1.RPAnswer
+	34, // 39: zdns.v1.Answer.talink:type_name -> zdns.v1.TALINKAnswer
+	35, // 40: zdns.v1.Answer.tkey:type_name -> zdns.v1.TKEYAnswer
+	38, // 41: zdns.v1.SVCBAnswer.svcparams:type_name -> google.protobuf.Struct
+	0,  // 42: zdns.v1.Result.ResultsEntry.value:type_name -> zdns.v1.Result
+	43, // [43:43] is the sub-list for method output_type
+	43, // [43:43] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_zdns_result_proto_init() }
+func file_zdns_result_proto_init() {
+	if File_zdns_result_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_zdns_result_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSFlags); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IPResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MXResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MXRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NSResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NSRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Answer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SOAAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SRVAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SVCBAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSKEYAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DSAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RRSIGAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CAAAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLSAAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SMIMEAAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NSECAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NSEC3Answer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NAPTRAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SSHFPAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*URIAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AFSDBAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CERTAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GPOSAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HINFOAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HIPAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LOCAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MINFOAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PXAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RPAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TALINKAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zdns_result_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TKEYAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_zdns_result_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Result_Dns)(nil),
+		(*Result_Ip)(nil),
+		(*Result_Mx)(nil),
+		(*Result_Ns)(nil),
+		(*Result_Other)(nil),
+	}
+	file_zdns_result_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*Answer_Answer)(nil),
+		(*Answer_Pref)(nil),
+		(*Answer_Soa)(nil),
+		(*Answer_Srv)(nil),
+		(*Answer_Svcb)(nil),
+		(*Answer_Dnskey)(nil),
+		(*Answer_Ds)(nil),
+		(*Answer_Rrsig)(nil),
+		(*Answer_Caa)(nil),
+		(*Answer_Tlsa)(nil),
+		(*Answer_Smimea)(nil),
+		(*Answer_Nsec)(nil),
+		(*Answer_Nsec3)(nil),
+		(*Answer_Naptr)(nil),
+		(*Answer_Sshfp)(nil),
+		(*Answer_Uri)(nil),
+		(*Answer_Afsdb)(nil),
+		(*Answer_Cert)(nil),
+		(*Answer_Gpos)(nil),
+		(*Answer_Hinfo)(nil),
+		(*Answer_Hip)(nil),
+		(*Answer_Loc)(nil),
+		(*Answer_Minfo)(nil),
+		(*Answer_Px)(nil),
+		(*Answer_Rp)(nil),
+		(*Answer_Talink)(nil),
+		(*Answer_Tkey)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_zdns_result_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_zdns_result_proto_goTypes,
+		DependencyIndexes: file_zdns_result_proto_depIdxs,
+		MessageInfos:      file_zdns_result_proto_msgTypes,
+	}.Build()
+	File_zdns_result_proto = out.File
+	file_zdns_result_proto_rawDesc = nil
+	file_zdns_result_proto_goTypes = nil
+	file_zdns_result_proto_depIdxs = nil
+}
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: zdns.proto
+
+package zdnspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ZdnsClient is the client API for Zdns service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ZdnsClient interface {
+	// Lookup performs a lookup for every TargetedDomain sent on the stream.
+	// Results are streamed back as lookups complete, which is not necessarily
+	// the order in which they were sent. The server stops reading requests
+	// while all of its lookup routines are busy.
+	Lookup(ctx context.Context, opts ...grpc.CallOption) (Zdns_LookupClient, error)
+}
+
+type zdnsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewZdnsClient(cc grpc.ClientConnInterface) ZdnsClient {
+	return &zdnsClient{cc}
+}
+
+func (c *zdnsClient) Lookup(ctx context.Context, opts ...grpc.CallOption) (Zdns_LookupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zdns_ServiceDesc.Streams[0], "/zdns.Zdns/Lookup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zdnsLookupClient{stream}
+	return x, nil
+}
+
+type Zdns_LookupClient interface {
+	Send(*TargetedDomain) error
+	Recv() (*Result, error)
+	grpc.ClientStream
+}
+
+type zdnsLookupClient struct {
+	grpc.ClientStream
+}
+
+func (x *zdnsLookupClient) Send(m *TargetedDomain) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *zdnsLookupClient) Recv() (*Result, error) {
+	m := new(Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ZdnsServer is the server API for Zdns service.
+// All implementations must embed UnimplementedZdnsServer
+// for forward compatibility
+type ZdnsServer interface {
+	// Lookup performs a lookup for every TargetedDomain sent on the stream.
+	// Results are streamed back as lookups complete, which is not necessarily
+	// the order in which they were sent. The server stops reading requests
+	// while all of its lookup routines are busy.
+	Lookup(Zdns_LookupServer) error
+	mustEmbedUnimplementedZdnsServer()
+}
+
+// UnimplementedZdnsServer must be embedded to have forward compatible implementations.
+type UnimplementedZdnsServer struct {
+}
+
+func (UnimplementedZdnsServer) Lookup(Zdns_LookupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedZdnsServer) mustEmbedUnimplementedZdnsServer() {}
+
+// UnsafeZdnsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ZdnsServer will
+// result in compilation errors.
+type UnsafeZdnsServer interface {
+	mustEmbedUnimplementedZdnsServer()
+}
+
+func RegisterZdnsServer(s grpc.ServiceRegistrar, srv ZdnsServer) {
+	s.RegisterService(&Zdns_ServiceDesc, srv)
+}
+
+func _Zdns_Lookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZdnsServer).Lookup(&zdnsLookupServer{stream})
+}
+
+type Zdns_LookupServer interface {
+	Send(*Result) error
+	Recv() (*TargetedDomain, error)
+	grpc.ServerStream
+}
+
+type zdnsLookupServer struct {
+	grpc.ServerStream
+}
+
+func (x *zdnsLookupServer) Send(m *Result) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *zdnsLookupServer) Recv() (*TargetedDomain, error) {
+	m := new(TargetedDomain)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Zdns_ServiceDesc is the grpc.ServiceDesc for Zdns service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Zdns_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zdns.Zdns",
+	HandlerType: (*ZdnsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Lookup",
+			Handler:       _Zdns_Lookup_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "zdns.proto",
+}